@@ -57,7 +57,7 @@ resource "gtm_tag" "test_tag_1" {
       }]
     },
     {
-      key   = "measurementId",
+      key   = "measurementIdOverride",
       type  = "template"
       value = "G-A2ABC2ABCD"
     }
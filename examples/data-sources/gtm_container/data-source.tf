@@ -0,0 +1,3 @@
+data "gtm_container" "website" {
+  public_id = "GTM-XXXXXXX"
+}
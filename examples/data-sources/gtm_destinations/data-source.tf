@@ -0,0 +1,5 @@
+data "gtm_destinations" "linked" {}
+
+output "linked_destination_ids" {
+  value = [for d in data.gtm_destinations.linked.destination : d.destination_id]
+}
@@ -0,0 +1,5 @@
+data "gtm_user_permissions" "all" {}
+
+output "emails_with_access" {
+  value = [for p in data.gtm_user_permissions.all.user_permission : p.email_address]
+}
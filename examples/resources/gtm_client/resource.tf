@@ -0,0 +1,13 @@
+resource "gtm_client" "ga4" {
+  name     = "GA4"
+  type     = "gaaw_client"
+  priority = 10
+  notes    = "Generated by terraform. Do not edit it."
+  parameter = [
+    {
+      key   = "activateDefaultPaths"
+      type  = "boolean"
+      value = "true"
+    }
+  ]
+}
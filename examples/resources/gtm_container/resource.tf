@@ -0,0 +1,6 @@
+resource "gtm_container" "website" {
+  name          = "www.example.com"
+  usage_context = ["web"]
+  domain_name   = ["example.com", "www.example.com"]
+  notes         = "Generated by terraform. Do not edit it."
+}
@@ -0,0 +1,14 @@
+resource "gtm_custom_template" "consent" {
+  name          = "Consent mode"
+  template_data = file("${path.module}/templates/consent-mode.tpl")
+  gallery_reference = {
+    owner      = "gtm-templates-simo-ahava"
+    repository = "consent-mode"
+    version    = "d2f8ab4a1bfb16ba4ffab4cd1a58fb0a8d4d6b5f"
+  }
+}
+
+resource "gtm_tag" "consent" {
+  name = "Consent mode"
+  type = gtm_custom_template.consent.type
+}
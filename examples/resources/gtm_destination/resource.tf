@@ -0,0 +1,3 @@
+resource "gtm_destination" "ga4" {
+  destination_id = "G-A2ABC2ABCD"
+}
@@ -0,0 +1,9 @@
+resource "gtm_environment" "staging" {
+  name         = "staging"
+  description  = "Generated by terraform. Do not edit it."
+  url          = "https://staging.example.com"
+  enable_debug = true
+
+  # Change this value to rotate the authorization code.
+  reauthorize = "2023-07-01"
+}
@@ -0,0 +1,9 @@
+resource "gtm_ga4_config_tag" "ga4" {
+  name           = "GA4 - configuration"
+  measurement_id = "G-A2ABC2ABCD"
+  send_page_view = true
+  fields_to_set = {
+    cookie_domain = "auto"
+  }
+  firing_trigger_id = ["2147479553"] # All Pages
+}
@@ -0,0 +1,13 @@
+resource "gtm_ga4_event_tag" "purchase" {
+  name           = "GA4 - purchase"
+  measurement_id = "G-A2ABC2ABCD"
+  event_name     = "purchase"
+  event_parameters = {
+    currency = "{{currency}}"
+    value    = "{{order value}}"
+  }
+  user_properties = {
+    customer_type = "{{customer type}}"
+  }
+  firing_trigger_id = [gtm_trigger.purchase.id]
+}
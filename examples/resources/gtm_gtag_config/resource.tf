@@ -0,0 +1,29 @@
+resource "gtm_gtag_config" "ga4" {
+  type = "googtag"
+  parameter = [
+    {
+      key   = "tagId"
+      type  = "template"
+      value = "G-A2ABC2ABCD"
+    },
+    {
+      key  = "configSettingsTable"
+      type = "list"
+      list = [{
+        type = "map"
+        map = [
+          {
+            type  = "template"
+            key   = "parameter"
+            value = "send_page_view"
+          },
+          {
+            type  = "template"
+            key   = "parameterValue"
+            value = "false"
+          }
+        ]
+      }]
+    }
+  ]
+}
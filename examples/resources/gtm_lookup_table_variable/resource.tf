@@ -0,0 +1,23 @@
+resource "gtm_lookup_table_variable" "measurement_id" {
+  name  = "GA4 measurement ID"
+  input = "{{Page Hostname}}"
+  rows = [
+    { pattern = "www.example.com", output = "G-A2ABC2ABCD" },
+    { pattern = "staging.example.com", output = "G-B3BCD3BCDE" },
+  ]
+  default_value = "G-C4CDE4CDEF"
+}
+
+resource "gtm_lookup_table_variable" "page_section" {
+  name  = "page section"
+  input = "{{Page Path}}"
+  rows = [
+    { pattern = "^/checkout(/.*)?$", output = "checkout" },
+    { pattern = "^/(blog|news)/", output = "$1" },
+    { pattern = ".*", output = "other" },
+  ]
+  regex = {
+    capture_groups = true
+    full_match     = false
+  }
+}
@@ -27,10 +27,25 @@ resource "gtm_tag" "test_tag_1" {
       }]
     },
     {
-      key   = "measurementId",
+      key   = "measurementIdOverride",
       type  = "template"
       value = "G-A2ABC2ABCD"
     }
   ]
   firing_trigger_id = [gtm_trigger.test_trigger_1.id]
 }
+
+# The same tag with parameters inferred from a JSON object.
+resource "gtm_tag" "test_tag_2" {
+  name  = "test tag 2"
+  type  = "gaawe"
+  notes = "Generated by terraform. Do not edit it."
+  parameters = jsonencode({
+    eventName             = "event1"
+    measurementIdOverride = "G-A2ABC2ABCD"
+    eventParameters = [
+      { name = "eventName", value = "eventValue" }
+    ]
+  })
+  firing_trigger_id = [gtm_trigger.test_trigger_1.id]
+}
@@ -0,0 +1,21 @@
+resource "gtm_transformation" "exclude_ip" {
+  name  = "Exclude IP override"
+  type  = "tf_exclude_params"
+  notes = "Generated by terraform. Do not edit it."
+  parameter = [
+    {
+      key  = "excludeList"
+      type = "list"
+      list = [{
+        type = "map"
+        map = [
+          {
+            type  = "template"
+            key   = "paramName"
+            value = "ip_override"
+          }
+        ]
+      }]
+    }
+  ]
+}
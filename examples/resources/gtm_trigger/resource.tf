@@ -19,3 +19,21 @@ resource "gtm_trigger" "test_trigger_1" {
     }
   ]
 }
+
+# The same kind of conditions written with simple_filter.
+resource "gtm_trigger" "checkout_purchase" {
+  name = "checkout purchase"
+  type = "customEvent"
+  simple_filter = [
+    {
+      variable = "event"
+      operator = "equals"
+      value    = "purchase"
+    },
+    {
+      variable = "page_path"
+      operator = "contains"
+      value    = "/checkout"
+    }
+  ]
+}
@@ -0,0 +1,10 @@
+resource "gtm_user_permission" "analyst" {
+  email_address  = "analyst@example.com"
+  account_access = "user"
+  container_access = [
+    {
+      container_id = "119458552"
+      permission   = "edit"
+    }
+  ]
+}
@@ -11,3 +11,27 @@ resource "gtm_variable" "test_variable" {
     }
   ]
 }
+
+# Parameters nested deeper than parameter supports, e.g. tables of tables
+# in custom template variables, are written with parameter_json.
+resource "gtm_variable" "nested_table" {
+  name = "nested table"
+  type = "cvt_12345678_9"
+  parameter_json = jsonencode([
+    {
+      key  = "rows"
+      type = "list"
+      list = [{
+        type = "map"
+        map = [{
+          key  = "columns"
+          type = "list"
+          list = [{
+            type = "map"
+            map  = [{ key = "name", type = "template", value = "value" }]
+          }]
+        }]
+      }]
+    }
+  ])
+}
@@ -0,0 +1,33 @@
+resource "gtm_zone" "partner" {
+  name  = "Partner zone"
+  notes = "Generated by terraform. Do not edit it."
+  child_container = [
+    {
+      public_id = "GTM-XXXXXXX"
+      nickname  = "Partner container"
+    }
+  ]
+  boundary = {
+    condition = [
+      {
+        type = "contains"
+        parameter = [
+          {
+            type  = "template"
+            key   = "arg0"
+            value = "{{Page Path}}"
+          },
+          {
+            type  = "template"
+            key   = "arg1"
+            value = "/partner"
+          }
+        ]
+      }
+    ]
+  }
+  type_restriction = {
+    enable              = true
+    whitelisted_type_id = ["google"]
+  }
+}
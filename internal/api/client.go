@@ -3,11 +3,14 @@ package api
 import (
 	"context"
 	"errors"
+	"net/http"
+	"strings"
 	"time"
 
 	"google.golang.org/api/googleapi"
 	"google.golang.org/api/option"
 	"google.golang.org/api/tagmanager/v2"
+	htransport "google.golang.org/api/transport/http"
 )
 
 type ClientOptions struct {
@@ -21,12 +24,31 @@ type Client struct {
 	*tagmanager.Service
 
 	Options *ClientOptions
+
+	// ctx is the context of the API calls, which carries the loggers of the
+	// operation that makes them.
+	ctx context.Context
 }
 
 func NewClient(opts *ClientOptions) (*Client, error) {
 	var ctx = context.Background()
 
-	srv, err := tagmanager.NewService(ctx, option.WithCredentialsFile(opts.CredentialFile))
+	transport, err := htransport.NewTransport(ctx, loggingTransport{next: http.DefaultTransport},
+		option.WithCredentialsFile(opts.CredentialFile),
+		option.WithScopes(
+			tagmanager.TagmanagerDeleteContainersScope,
+			tagmanager.TagmanagerEditContainersScope,
+			tagmanager.TagmanagerEditContainerversionsScope,
+			tagmanager.TagmanagerManageAccountsScope,
+			tagmanager.TagmanagerManageUsersScope,
+			tagmanager.TagmanagerPublishScope,
+			tagmanager.TagmanagerReadonlyScope,
+		))
+	if err != nil {
+		return nil, err
+	}
+
+	srv, err := tagmanager.NewService(ctx, option.WithHTTPClient(&http.Client{Transport: transport}))
 	if err != nil {
 		return nil, err
 	}
@@ -34,9 +56,41 @@ func NewClient(opts *ClientOptions) (*Client, error) {
 	return &Client{Service: srv, Options: opts}, nil
 }
 
+// InContainer returns a client sharing the same service but bound to the given
+// account and container. Empty arguments fall back to the options of c.
+func (c *Client) InContainer(accountId string, containerId string) *Client {
+	opts := *c.Options
+	if accountId != "" {
+		opts.AccountId = accountId
+	}
+	if containerId != "" {
+		opts.ContainerId = containerId
+	}
+
+	return &Client{Service: c.Service, Options: &opts, ctx: c.ctx}
+}
+
+// WithContext returns a client sharing the same service and options whose API
+// calls are made, and logged, in ctx.
+func (c *Client) WithContext(ctx context.Context) *Client {
+	return &Client{Service: c.Service, Options: c.Options, ctx: ctx}
+}
+
+// callContext returns the context of the API calls.
+func (c *Client) callContext() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+
+	return c.ctx
+}
+
+func (c *Client) accountPath() string {
+	return "accounts/" + c.Options.AccountId
+}
+
 func (c *Client) containerPath() string {
-	opts := c.Options
-	return "accounts/" + opts.AccountId + "/containers/" + opts.ContainerId
+	return c.accountPath() + "/containers/" + c.Options.ContainerId
 }
 
 func (c *Client) beforeEachQuery() {
@@ -45,14 +99,175 @@ func (c *Client) beforeEachQuery() {
 
 var ErrNotExist = errors.New("not exist")
 
+func (c *Client) CreateContainer(container *tagmanager.Container) (*tagmanager.Container, error) {
+	c.beforeEachQuery()
+	return c.Accounts.Containers.Create(c.accountPath(), container).Context(c.callContext()).Do()
+}
+
+func (c *Client) ListContainers() ([]*tagmanager.Container, error) {
+	c.beforeEachQuery()
+	resp, err := c.Accounts.Containers.List(c.accountPath()).Context(c.callContext()).Do()
+	if err != nil {
+		return nil, err
+	} else {
+		return resp.Container, nil
+	}
+}
+
+func (c *Client) Container(id string) (*tagmanager.Container, error) {
+	c.beforeEachQuery()
+	container, err := c.Accounts.Containers.Get(c.accountPath() + "/containers/" + id).Context(c.callContext()).Do()
+
+	if errTyped, ok := err.(*googleapi.Error); ok && errTyped.Code == 404 {
+		return nil, ErrNotExist
+	} else {
+		return container, err
+	}
+}
+
+func (c *Client) UpdateContainer(id string, container *tagmanager.Container) (*tagmanager.Container, error) {
+	c.beforeEachQuery()
+	return c.Accounts.Containers.Update(c.accountPath()+"/containers/"+id, container).Context(c.callContext()).Do()
+}
+
+func (c *Client) DeleteContainer(id string) error {
+	c.beforeEachQuery()
+	return c.Accounts.Containers.Delete(c.accountPath() + "/containers/" + id).Context(c.callContext()).Do()
+}
+
+func (c *Client) userPermissionPath(id string) string {
+	return c.accountPath() + "/user_permissions/" + id
+}
+
+func (c *Client) CreateUserPermission(permission *tagmanager.UserPermission) (*tagmanager.UserPermission, error) {
+	c.beforeEachQuery()
+	return c.Accounts.UserPermissions.Create(c.accountPath(), permission).Context(c.callContext()).Do()
+}
+
+func (c *Client) ListUserPermissions() ([]*tagmanager.UserPermission, error) {
+	c.beforeEachQuery()
+	resp, err := c.Accounts.UserPermissions.List(c.accountPath()).Context(c.callContext()).Do()
+	if err != nil {
+		return nil, err
+	} else {
+		return resp.UserPermission, nil
+	}
+}
+
+func (c *Client) UserPermission(id string) (*tagmanager.UserPermission, error) {
+	c.beforeEachQuery()
+	permission, err := c.Accounts.UserPermissions.Get(c.userPermissionPath(id)).Context(c.callContext()).Do()
+
+	if errTyped, ok := err.(*googleapi.Error); ok && errTyped.Code == 404 {
+		return nil, ErrNotExist
+	} else {
+		return permission, err
+	}
+}
+
+func (c *Client) UpdateUserPermission(id string, permission *tagmanager.UserPermission) (*tagmanager.UserPermission, error) {
+	c.beforeEachQuery()
+	return c.Accounts.UserPermissions.Update(c.userPermissionPath(id), permission).Context(c.callContext()).Do()
+}
+
+func (c *Client) DeleteUserPermission(id string) error {
+	c.beforeEachQuery()
+	return c.Accounts.UserPermissions.Delete(c.userPermissionPath(id)).Context(c.callContext()).Do()
+}
+
+// UserPermissionId extracts the ID of a user permission from its path, since
+// the API has no separate ID field for it.
+func UserPermissionId(permission *tagmanager.UserPermission) string {
+	return permission.Path[strings.LastIndex(permission.Path, "/")+1:]
+}
+
+func (c *Client) environmentPath(id string) string {
+	return c.containerPath() + "/environments/" + id
+}
+
+func (c *Client) CreateEnvironment(env *tagmanager.Environment) (*tagmanager.Environment, error) {
+	c.beforeEachQuery()
+	return c.Accounts.Containers.Environments.Create(c.containerPath(), env).Context(c.callContext()).Do()
+}
+
+func (c *Client) ListEnvironments() ([]*tagmanager.Environment, error) {
+	c.beforeEachQuery()
+	resp, err := c.Accounts.Containers.Environments.List(c.containerPath()).Context(c.callContext()).Do()
+	if err != nil {
+		return nil, err
+	} else {
+		return resp.Environment, nil
+	}
+}
+
+func (c *Client) Environment(id string) (*tagmanager.Environment, error) {
+	c.beforeEachQuery()
+	env, err := c.Accounts.Containers.Environments.Get(c.environmentPath(id)).Context(c.callContext()).Do()
+
+	if errTyped, ok := err.(*googleapi.Error); ok && errTyped.Code == 404 {
+		return nil, ErrNotExist
+	} else {
+		return env, err
+	}
+}
+
+func (c *Client) UpdateEnvironment(id string, env *tagmanager.Environment) (*tagmanager.Environment, error) {
+	c.beforeEachQuery()
+	return c.Accounts.Containers.Environments.Update(c.environmentPath(id), env).Context(c.callContext()).Do()
+}
+
+// ReauthorizeEnvironment re-generates the authorization code of an environment.
+func (c *Client) ReauthorizeEnvironment(id string) (*tagmanager.Environment, error) {
+	c.beforeEachQuery()
+	return c.Accounts.Containers.Environments.Reauthorize(c.environmentPath(id), &tagmanager.Environment{}).Context(c.callContext()).Do()
+}
+
+func (c *Client) DeleteEnvironment(id string) error {
+	c.beforeEachQuery()
+	return c.Accounts.Containers.Environments.Delete(c.environmentPath(id)).Context(c.callContext()).Do()
+}
+
+// LinkDestination links a destination, e.g. a GA4 measurement ID, to the
+// container and unlinks it from the container it was linked to before. The
+// API offers no way to unlink a destination otherwise.
+func (c *Client) LinkDestination(destinationId string, allowUserPermissionFeatureUpdate bool) (*tagmanager.Destination, error) {
+	c.beforeEachQuery()
+	return c.Accounts.Containers.Destinations.Link(c.containerPath()).
+		DestinationId(destinationId).
+		AllowUserPermissionFeatureUpdate(allowUserPermissionFeatureUpdate).
+		Context(c.callContext()).
+		Do()
+}
+
+func (c *Client) ListDestinations() ([]*tagmanager.Destination, error) {
+	c.beforeEachQuery()
+	resp, err := c.Accounts.Containers.Destinations.List(c.containerPath()).Context(c.callContext()).Do()
+	if err != nil {
+		return nil, err
+	} else {
+		return resp.Destination, nil
+	}
+}
+
+func (c *Client) Destination(linkId string) (*tagmanager.Destination, error) {
+	c.beforeEachQuery()
+	destination, err := c.Accounts.Containers.Destinations.Get(c.containerPath() + "/destinations/" + linkId).Context(c.callContext()).Do()
+
+	if errTyped, ok := err.(*googleapi.Error); ok && errTyped.Code == 404 {
+		return nil, ErrNotExist
+	} else {
+		return destination, err
+	}
+}
+
 func (c *Client) CreateWorkspace(ws *tagmanager.Workspace) (*tagmanager.Workspace, error) {
 	c.beforeEachQuery()
-	return c.Accounts.Containers.Workspaces.Create(c.containerPath(), ws).Do()
+	return c.Accounts.Containers.Workspaces.Create(c.containerPath(), ws).Context(c.callContext()).Do()
 }
 
 func (c *Client) ListWorkspaces() ([]*tagmanager.Workspace, error) {
 	c.beforeEachQuery()
-	resp, err := c.Accounts.Containers.Workspaces.List(c.containerPath()).Do()
+	resp, err := c.Accounts.Containers.Workspaces.List(c.containerPath()).Context(c.callContext()).Do()
 	if err != nil {
 		return nil, err
 	} else {
@@ -62,7 +277,7 @@ func (c *Client) ListWorkspaces() ([]*tagmanager.Workspace, error) {
 
 func (c *Client) Workspace(id string) (*tagmanager.Workspace, error) {
 	c.beforeEachQuery()
-	ws, err := c.Accounts.Containers.Workspaces.Get(c.containerPath() + "/workspaces/" + id).Do()
+	ws, err := c.Accounts.Containers.Workspaces.Get(c.containerPath() + "/workspaces/" + id).Context(c.callContext()).Do()
 
 	if errTyped, ok := err.(*googleapi.Error); ok && errTyped.Code == 404 {
 		return nil, ErrNotExist
@@ -73,12 +288,12 @@ func (c *Client) Workspace(id string) (*tagmanager.Workspace, error) {
 
 func (c *Client) UpdateWorkspaces(id string, ws *tagmanager.Workspace) (*tagmanager.Workspace, error) {
 	c.beforeEachQuery()
-	return c.Accounts.Containers.Workspaces.Update(c.containerPath()+"/workspaces/"+id, ws).Do()
+	return c.Accounts.Containers.Workspaces.Update(c.containerPath()+"/workspaces/"+id, ws).Context(c.callContext()).Do()
 }
 
 func (c *Client) DeleteWorkspace(id string) error {
 	c.beforeEachQuery()
-	return c.Accounts.Containers.Workspaces.Delete(c.containerPath() + "/workspaces/" + id).Do()
+	return c.Accounts.Containers.Workspaces.Delete(c.containerPath() + "/workspaces/" + id).Context(c.callContext()).Do()
 }
 
 func (c *Client) workspacePath(id string) string {
@@ -87,12 +302,12 @@ func (c *Client) workspacePath(id string) string {
 
 func (c *Client) CreateTag(workspaceId string, tag *tagmanager.Tag) (*tagmanager.Tag, error) {
 	c.beforeEachQuery()
-	return c.Accounts.Containers.Workspaces.Tags.Create(c.workspacePath(workspaceId), tag).Do()
+	return c.Accounts.Containers.Workspaces.Tags.Create(c.workspacePath(workspaceId), tag).Context(c.callContext()).Do()
 }
 
 func (c *Client) ListTags(workspaceId string) ([]*tagmanager.Tag, error) {
 	c.beforeEachQuery()
-	resp, err := c.Accounts.Containers.Workspaces.Tags.List(c.workspacePath(workspaceId)).Do()
+	resp, err := c.Accounts.Containers.Workspaces.Tags.List(c.workspacePath(workspaceId)).Context(c.callContext()).Do()
 	if err != nil {
 		return nil, err
 	} else {
@@ -102,7 +317,7 @@ func (c *Client) ListTags(workspaceId string) ([]*tagmanager.Tag, error) {
 
 func (c *Client) Tag(workspaceId string, tagId string) (*tagmanager.Tag, error) {
 	c.beforeEachQuery()
-	tag, err := c.Accounts.Containers.Workspaces.Tags.Get(c.workspacePath(workspaceId) + "/tags/" + tagId).Do()
+	tag, err := c.Accounts.Containers.Workspaces.Tags.Get(c.workspacePath(workspaceId) + "/tags/" + tagId).Context(c.callContext()).Do()
 
 	if errTyped, ok := err.(*googleapi.Error); ok && errTyped.Code == 404 {
 		return nil, ErrNotExist
@@ -113,22 +328,22 @@ func (c *Client) Tag(workspaceId string, tagId string) (*tagmanager.Tag, error)
 
 func (c *Client) UpdateTag(workspaceId string, tagId string, tag *tagmanager.Tag) (*tagmanager.Tag, error) {
 	c.beforeEachQuery()
-	return c.Accounts.Containers.Workspaces.Tags.Update(c.workspacePath(workspaceId)+"/tags/"+tagId, tag).Do()
+	return c.Accounts.Containers.Workspaces.Tags.Update(c.workspacePath(workspaceId)+"/tags/"+tagId, tag).Context(c.callContext()).Do()
 }
 
 func (c *Client) DeleteTag(workspaceId string, tagId string) error {
 	c.beforeEachQuery()
-	return c.Accounts.Containers.Workspaces.Tags.Delete(c.workspacePath(workspaceId) + "/tags/" + tagId).Do()
+	return c.Accounts.Containers.Workspaces.Tags.Delete(c.workspacePath(workspaceId) + "/tags/" + tagId).Context(c.callContext()).Do()
 }
 
 func (c *Client) CreateVariable(workspaceId string, variable *tagmanager.Variable) (*tagmanager.Variable, error) {
 	c.beforeEachQuery()
-	return c.Accounts.Containers.Workspaces.Variables.Create(c.workspacePath(workspaceId), variable).Do()
+	return c.Accounts.Containers.Workspaces.Variables.Create(c.workspacePath(workspaceId), variable).Context(c.callContext()).Do()
 }
 
 func (c *Client) ListVariables(workspaceId string) ([]*tagmanager.Variable, error) {
 	c.beforeEachQuery()
-	resp, err := c.Accounts.Containers.Workspaces.Variables.List(c.workspacePath(workspaceId)).Do()
+	resp, err := c.Accounts.Containers.Workspaces.Variables.List(c.workspacePath(workspaceId)).Context(c.callContext()).Do()
 	if err != nil {
 		return nil, err
 	} else {
@@ -136,9 +351,19 @@ func (c *Client) ListVariables(workspaceId string) ([]*tagmanager.Variable, erro
 	}
 }
 
+func (c *Client) ListBuiltInVariables(workspaceId string) ([]*tagmanager.BuiltInVariable, error) {
+	c.beforeEachQuery()
+	resp, err := c.Accounts.Containers.Workspaces.BuiltInVariables.List(c.workspacePath(workspaceId)).Context(c.callContext()).Do()
+	if err != nil {
+		return nil, err
+	} else {
+		return resp.BuiltInVariable, nil
+	}
+}
+
 func (c *Client) Variable(workspaceId string, variableId string) (*tagmanager.Variable, error) {
 	c.beforeEachQuery()
-	variable, err := c.Accounts.Containers.Workspaces.Variables.Get(c.workspacePath(workspaceId) + "/variables/" + variableId).Do()
+	variable, err := c.Accounts.Containers.Workspaces.Variables.Get(c.workspacePath(workspaceId) + "/variables/" + variableId).Context(c.callContext()).Do()
 
 	if errTyped, ok := err.(*googleapi.Error); ok && errTyped.Code == 404 {
 		return nil, ErrNotExist
@@ -149,22 +374,22 @@ func (c *Client) Variable(workspaceId string, variableId string) (*tagmanager.Va
 
 func (c *Client) UpdateVariable(workspaceId string, variableId string, variable *tagmanager.Variable) (*tagmanager.Variable, error) {
 	c.beforeEachQuery()
-	return c.Accounts.Containers.Workspaces.Variables.Update(c.workspacePath(workspaceId)+"/variables/"+variableId, variable).Do()
+	return c.Accounts.Containers.Workspaces.Variables.Update(c.workspacePath(workspaceId)+"/variables/"+variableId, variable).Context(c.callContext()).Do()
 }
 
 func (c *Client) DeleteVariable(workspaceId string, variableId string) error {
 	c.beforeEachQuery()
-	return c.Accounts.Containers.Workspaces.Variables.Delete(c.workspacePath(workspaceId) + "/variables/" + variableId).Do()
+	return c.Accounts.Containers.Workspaces.Variables.Delete(c.workspacePath(workspaceId) + "/variables/" + variableId).Context(c.callContext()).Do()
 }
 
 func (c *Client) CreateTrigger(workspaceId string, trigger *tagmanager.Trigger) (*tagmanager.Trigger, error) {
 	c.beforeEachQuery()
-	return c.Accounts.Containers.Workspaces.Triggers.Create(c.workspacePath(workspaceId), trigger).Do()
+	return c.Accounts.Containers.Workspaces.Triggers.Create(c.workspacePath(workspaceId), trigger).Context(c.callContext()).Do()
 }
 
 func (c *Client) ListTriggers(workspaceId string) ([]*tagmanager.Trigger, error) {
 	c.beforeEachQuery()
-	resp, err := c.Accounts.Containers.Workspaces.Triggers.List(c.workspacePath(workspaceId)).Do()
+	resp, err := c.Accounts.Containers.Workspaces.Triggers.List(c.workspacePath(workspaceId)).Context(c.callContext()).Do()
 	if err != nil {
 		return nil, err
 	} else {
@@ -174,7 +399,7 @@ func (c *Client) ListTriggers(workspaceId string) ([]*tagmanager.Trigger, error)
 
 func (c *Client) Trigger(workspaceId string, triggerId string) (*tagmanager.Trigger, error) {
 	c.beforeEachQuery()
-	trigger, err := c.Accounts.Containers.Workspaces.Triggers.Get(c.workspacePath(workspaceId) + "/triggers/" + triggerId).Do()
+	trigger, err := c.Accounts.Containers.Workspaces.Triggers.Get(c.workspacePath(workspaceId) + "/triggers/" + triggerId).Context(c.callContext()).Do()
 
 	if errTyped, ok := err.(*googleapi.Error); ok && errTyped.Code == 404 {
 		return nil, ErrNotExist
@@ -185,10 +410,192 @@ func (c *Client) Trigger(workspaceId string, triggerId string) (*tagmanager.Trig
 
 func (c *Client) UpdateTrigger(workspaceId string, triggerId string, trigger *tagmanager.Trigger) (*tagmanager.Trigger, error) {
 	c.beforeEachQuery()
-	return c.Accounts.Containers.Workspaces.Triggers.Update(c.workspacePath(workspaceId)+"/triggers/"+triggerId, trigger).Do()
+	return c.Accounts.Containers.Workspaces.Triggers.Update(c.workspacePath(workspaceId)+"/triggers/"+triggerId, trigger).Context(c.callContext()).Do()
 }
 
 func (c *Client) DeleteTrigger(workspaceId string, triggerId string) error {
 	c.beforeEachQuery()
-	return c.Accounts.Containers.Workspaces.Triggers.Delete(c.workspacePath(workspaceId) + "/triggers/" + triggerId).Do()
+	return c.Accounts.Containers.Workspaces.Triggers.Delete(c.workspacePath(workspaceId) + "/triggers/" + triggerId).Context(c.callContext()).Do()
+}
+
+// CreateServerClient creates a client of a server-side container. The client
+// methods are prefixed with Server to avoid clashing with the Client type.
+func (c *Client) CreateServerClient(workspaceId string, client *tagmanager.Client) (*tagmanager.Client, error) {
+	c.beforeEachQuery()
+	return c.Accounts.Containers.Workspaces.Clients.Create(c.workspacePath(workspaceId), client).Context(c.callContext()).Do()
+}
+
+func (c *Client) ListServerClients(workspaceId string) ([]*tagmanager.Client, error) {
+	c.beforeEachQuery()
+	resp, err := c.Accounts.Containers.Workspaces.Clients.List(c.workspacePath(workspaceId)).Context(c.callContext()).Do()
+	if err != nil {
+		return nil, err
+	} else {
+		return resp.Client, nil
+	}
+}
+
+func (c *Client) ServerClient(workspaceId string, clientId string) (*tagmanager.Client, error) {
+	c.beforeEachQuery()
+	client, err := c.Accounts.Containers.Workspaces.Clients.Get(c.workspacePath(workspaceId) + "/clients/" + clientId).Context(c.callContext()).Do()
+
+	if errTyped, ok := err.(*googleapi.Error); ok && errTyped.Code == 404 {
+		return nil, ErrNotExist
+	} else {
+		return client, err
+	}
+}
+
+func (c *Client) UpdateServerClient(workspaceId string, clientId string, client *tagmanager.Client) (*tagmanager.Client, error) {
+	c.beforeEachQuery()
+	return c.Accounts.Containers.Workspaces.Clients.Update(c.workspacePath(workspaceId)+"/clients/"+clientId, client).Context(c.callContext()).Do()
+}
+
+func (c *Client) DeleteServerClient(workspaceId string, clientId string) error {
+	c.beforeEachQuery()
+	return c.Accounts.Containers.Workspaces.Clients.Delete(c.workspacePath(workspaceId) + "/clients/" + clientId).Context(c.callContext()).Do()
+}
+
+func (c *Client) CreateTransformation(workspaceId string, transformation *tagmanager.Transformation) (*tagmanager.Transformation, error) {
+	c.beforeEachQuery()
+	return c.Accounts.Containers.Workspaces.Transformations.Create(c.workspacePath(workspaceId), transformation).Context(c.callContext()).Do()
+}
+
+func (c *Client) ListTransformations(workspaceId string) ([]*tagmanager.Transformation, error) {
+	c.beforeEachQuery()
+	resp, err := c.Accounts.Containers.Workspaces.Transformations.List(c.workspacePath(workspaceId)).Context(c.callContext()).Do()
+	if err != nil {
+		return nil, err
+	} else {
+		return resp.Transformation, nil
+	}
+}
+
+func (c *Client) Transformation(workspaceId string, transformationId string) (*tagmanager.Transformation, error) {
+	c.beforeEachQuery()
+	transformation, err := c.Accounts.Containers.Workspaces.Transformations.Get(c.workspacePath(workspaceId) + "/transformations/" + transformationId).Context(c.callContext()).Do()
+
+	if errTyped, ok := err.(*googleapi.Error); ok && errTyped.Code == 404 {
+		return nil, ErrNotExist
+	} else {
+		return transformation, err
+	}
+}
+
+func (c *Client) UpdateTransformation(workspaceId string, transformationId string, transformation *tagmanager.Transformation) (*tagmanager.Transformation, error) {
+	c.beforeEachQuery()
+	return c.Accounts.Containers.Workspaces.Transformations.Update(c.workspacePath(workspaceId)+"/transformations/"+transformationId, transformation).Context(c.callContext()).Do()
+}
+
+func (c *Client) DeleteTransformation(workspaceId string, transformationId string) error {
+	c.beforeEachQuery()
+	return c.Accounts.Containers.Workspaces.Transformations.Delete(c.workspacePath(workspaceId) + "/transformations/" + transformationId).Context(c.callContext()).Do()
+}
+
+func (c *Client) CreateZone(workspaceId string, zone *tagmanager.Zone) (*tagmanager.Zone, error) {
+	c.beforeEachQuery()
+	return c.Accounts.Containers.Workspaces.Zones.Create(c.workspacePath(workspaceId), zone).Context(c.callContext()).Do()
+}
+
+func (c *Client) ListZones(workspaceId string) ([]*tagmanager.Zone, error) {
+	c.beforeEachQuery()
+	resp, err := c.Accounts.Containers.Workspaces.Zones.List(c.workspacePath(workspaceId)).Context(c.callContext()).Do()
+	if err != nil {
+		return nil, err
+	} else {
+		return resp.Zone, nil
+	}
+}
+
+func (c *Client) Zone(workspaceId string, zoneId string) (*tagmanager.Zone, error) {
+	c.beforeEachQuery()
+	zone, err := c.Accounts.Containers.Workspaces.Zones.Get(c.workspacePath(workspaceId) + "/zones/" + zoneId).Context(c.callContext()).Do()
+
+	if errTyped, ok := err.(*googleapi.Error); ok && errTyped.Code == 404 {
+		return nil, ErrNotExist
+	} else {
+		return zone, err
+	}
+}
+
+func (c *Client) UpdateZone(workspaceId string, zoneId string, zone *tagmanager.Zone) (*tagmanager.Zone, error) {
+	c.beforeEachQuery()
+	return c.Accounts.Containers.Workspaces.Zones.Update(c.workspacePath(workspaceId)+"/zones/"+zoneId, zone).Context(c.callContext()).Do()
+}
+
+func (c *Client) DeleteZone(workspaceId string, zoneId string) error {
+	c.beforeEachQuery()
+	return c.Accounts.Containers.Workspaces.Zones.Delete(c.workspacePath(workspaceId) + "/zones/" + zoneId).Context(c.callContext()).Do()
+}
+
+func (c *Client) CreateTemplate(workspaceId string, template *tagmanager.CustomTemplate) (*tagmanager.CustomTemplate, error) {
+	c.beforeEachQuery()
+	return c.Accounts.Containers.Workspaces.Templates.Create(c.workspacePath(workspaceId), template).Context(c.callContext()).Do()
+}
+
+func (c *Client) ListTemplates(workspaceId string) ([]*tagmanager.CustomTemplate, error) {
+	c.beforeEachQuery()
+	resp, err := c.Accounts.Containers.Workspaces.Templates.List(c.workspacePath(workspaceId)).Context(c.callContext()).Do()
+	if err != nil {
+		return nil, err
+	} else {
+		return resp.Template, nil
+	}
+}
+
+func (c *Client) Template(workspaceId string, templateId string) (*tagmanager.CustomTemplate, error) {
+	c.beforeEachQuery()
+	template, err := c.Accounts.Containers.Workspaces.Templates.Get(c.workspacePath(workspaceId) + "/templates/" + templateId).Context(c.callContext()).Do()
+
+	if errTyped, ok := err.(*googleapi.Error); ok && errTyped.Code == 404 {
+		return nil, ErrNotExist
+	} else {
+		return template, err
+	}
+}
+
+func (c *Client) UpdateTemplate(workspaceId string, templateId string, template *tagmanager.CustomTemplate) (*tagmanager.CustomTemplate, error) {
+	c.beforeEachQuery()
+	return c.Accounts.Containers.Workspaces.Templates.Update(c.workspacePath(workspaceId)+"/templates/"+templateId, template).Context(c.callContext()).Do()
+}
+
+func (c *Client) DeleteTemplate(workspaceId string, templateId string) error {
+	c.beforeEachQuery()
+	return c.Accounts.Containers.Workspaces.Templates.Delete(c.workspacePath(workspaceId) + "/templates/" + templateId).Context(c.callContext()).Do()
+}
+
+func (c *Client) CreateGtagConfig(workspaceId string, gtagConfig *tagmanager.GtagConfig) (*tagmanager.GtagConfig, error) {
+	c.beforeEachQuery()
+	return c.Accounts.Containers.Workspaces.GtagConfig.Create(c.workspacePath(workspaceId), gtagConfig).Context(c.callContext()).Do()
+}
+
+func (c *Client) ListGtagConfigs(workspaceId string) ([]*tagmanager.GtagConfig, error) {
+	c.beforeEachQuery()
+	resp, err := c.Accounts.Containers.Workspaces.GtagConfig.List(c.workspacePath(workspaceId)).Context(c.callContext()).Do()
+	if err != nil {
+		return nil, err
+	} else {
+		return resp.GtagConfig, nil
+	}
+}
+
+func (c *Client) GtagConfig(workspaceId string, gtagConfigId string) (*tagmanager.GtagConfig, error) {
+	c.beforeEachQuery()
+	gtagConfig, err := c.Accounts.Containers.Workspaces.GtagConfig.Get(c.workspacePath(workspaceId) + "/gtag_config/" + gtagConfigId).Context(c.callContext()).Do()
+
+	if errTyped, ok := err.(*googleapi.Error); ok && errTyped.Code == 404 {
+		return nil, ErrNotExist
+	} else {
+		return gtagConfig, err
+	}
+}
+
+func (c *Client) UpdateGtagConfig(workspaceId string, gtagConfigId string, gtagConfig *tagmanager.GtagConfig) (*tagmanager.GtagConfig, error) {
+	c.beforeEachQuery()
+	return c.Accounts.Containers.Workspaces.GtagConfig.Update(c.workspacePath(workspaceId)+"/gtag_config/"+gtagConfigId, gtagConfig).Context(c.callContext()).Do()
+}
+
+func (c *Client) DeleteGtagConfig(workspaceId string, gtagConfigId string) error {
+	c.beforeEachQuery()
+	return c.Accounts.Containers.Workspaces.GtagConfig.Delete(c.workspacePath(workspaceId) + "/gtag_config/" + gtagConfigId).Context(c.callContext()).Do()
 }
@@ -1,6 +1,11 @@
 package api
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
 	"google.golang.org/api/tagmanager/v2"
 )
 
@@ -8,6 +13,18 @@ type ClientInWorkspaceOptions struct {
 	*ClientOptions
 	WorkspaceName string
 	WorkspaceId   string
+
+	// AutoCreateWorkspace creates the workspace named WorkspaceName on first
+	// use if it does not exist. Otherwise a missing workspace is an error.
+	AutoCreateWorkspace bool
+
+	// StrictVariableReferences makes references to unknown variables an error
+	// rather than a warning when resources are planned.
+	StrictVariableReferences bool
+
+	// mu guards the lazy resolution of WorkspaceId, which the clients sharing
+	// the options share.
+	mu sync.Mutex
 }
 
 type ClientInWorkspace struct {
@@ -16,102 +33,502 @@ type ClientInWorkspace struct {
 	Options *ClientInWorkspaceOptions
 }
 
+// NewClientInWorkspace creates a client for a workspace. No API query is made
+// here: the workspace is looked up, and created if allowed, on first use.
 func NewClientInWorkspace(options *ClientInWorkspaceOptions) (*ClientInWorkspace, error) {
 	client, err := NewClient(options.ClientOptions)
 	if err != nil {
 		return nil, err
 	}
 
-	workspaces, err := client.ListWorkspaces()
+	return &ClientInWorkspace{
+		Client:  client,
+		Options: options,
+	}, nil
+}
+
+var ErrNoWorkspace = errors.New("no workspace is configured, set workspace_id or workspace_name")
+
+// WorkspaceId returns the ID of the workspace. If only a workspace name is
+// configured, the workspace is looked up by name and, if AutoCreateWorkspace
+// is set, created when missing. The result is cached.
+func (c *ClientInWorkspace) WorkspaceId() (string, error) {
+	return c.resolveWorkspaceId(true)
+}
+
+// ExistingWorkspaceId is like WorkspaceId but never creates the workspace, so
+// that it can be used while planning. It returns an empty ID when the
+// workspace does not exist yet and would be created on first use.
+func (c *ClientInWorkspace) ExistingWorkspaceId() (string, error) {
+	workspaceId, err := c.resolveWorkspaceId(false)
+	if errors.Is(err, errWorkspaceNotFound) {
+		return "", nil
+	}
+
+	return workspaceId, err
+}
+
+var errWorkspaceNotFound = errors.New("workspace not found")
+
+func (c *ClientInWorkspace) resolveWorkspaceId(create bool) (string, error) {
+	c.Options.mu.Lock()
+	defer c.Options.mu.Unlock()
+
+	if c.Options.WorkspaceId != "" {
+		return c.Options.WorkspaceId, nil
+	}
+
+	if c.Options.WorkspaceName == "" {
+		return "", ErrNoWorkspace
+	}
+
+	workspaces, err := c.ListWorkspaces()
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 
 	for _, workspace := range workspaces {
-		if workspace.Name == options.WorkspaceName {
-			options.WorkspaceId = workspace.WorkspaceId
-
-			return &ClientInWorkspace{
-				Client:  client,
-				Options: options,
-			}, nil
+		if workspace.Name == c.Options.WorkspaceName {
+			c.Options.WorkspaceId = workspace.WorkspaceId
+			return c.Options.WorkspaceId, nil
 		}
 	}
 
-	workspace, err := client.CreateWorkspace(&tagmanager.Workspace{Name: options.WorkspaceName})
+	if !c.Options.AutoCreateWorkspace {
+		return "", fmt.Errorf("workspace %q does not exist in %s and auto_create_workspace is disabled", c.Options.WorkspaceName, c.containerPath())
+	}
+
+	if !create {
+		return "", errWorkspaceNotFound
+	}
+
+	workspace, err := c.CreateWorkspace(&tagmanager.Workspace{Name: c.Options.WorkspaceName})
 	if err != nil {
-		return nil, err
-	} else {
-		options.WorkspaceId = workspace.WorkspaceId
-		return &ClientInWorkspace{
-			Client:  client,
-			Options: options,
-		}, nil
+		return "", err
 	}
+
+	c.Options.WorkspaceId = workspace.WorkspaceId
+	return c.Options.WorkspaceId, nil
+}
+
+// WithContext returns a client of the same workspace whose API calls are made,
+// and logged, in ctx.
+func (c *ClientInWorkspace) WithContext(ctx context.Context) *ClientInWorkspace {
+	return &ClientInWorkspace{Client: c.Client.WithContext(ctx), Options: c.Options}
+}
+
+var ErrWorkspaceRequired = errors.New("workspace_id is required when account_id or container_id differs from the provider configuration")
+
+// InWorkspace returns a client bound to the given account, container and
+// workspace. Empty arguments fall back to the options of c. A workspace ID must
+// be given whenever the account or container differs from that of c, since the
+// workspace of c does not exist in another container.
+func (c *ClientInWorkspace) InWorkspace(accountId string, containerId string, workspaceId string) (*ClientInWorkspace, error) {
+	client := c.Client.InContainer(accountId, containerId)
+	sameContainer := client.Options.AccountId == c.Client.Options.AccountId &&
+		client.Options.ContainerId == c.Client.Options.ContainerId
+
+	if workspaceId == "" {
+		if !sameContainer {
+			return nil, ErrWorkspaceRequired
+		}
+		// Share the lazily resolved default workspace.
+		return c, nil
+	}
+
+	return &ClientInWorkspace{
+		Client: client,
+		Options: &ClientInWorkspaceOptions{
+			ClientOptions:            client.Options,
+			WorkspaceId:              workspaceId,
+			StrictVariableReferences: c.Options.StrictVariableReferences,
+		},
+	}, nil
 }
 
 // Tag CRUD
 
 func (c *ClientInWorkspace) CreateTag(tag *tagmanager.Tag) (*tagmanager.Tag, error) {
-	return c.Client.CreateTag(c.Options.WorkspaceId, tag)
+	workspaceId, err := c.WorkspaceId()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Client.CreateTag(workspaceId, tag)
 }
 
 func (c *ClientInWorkspace) ListTags() ([]*tagmanager.Tag, error) {
-	return c.Client.ListTags(c.Options.WorkspaceId)
+	workspaceId, err := c.WorkspaceId()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Client.ListTags(workspaceId)
 }
 
 func (c *ClientInWorkspace) Tag(tagId string) (*tagmanager.Tag, error) {
-	return c.Client.Tag(c.Options.WorkspaceId, tagId)
+	workspaceId, err := c.WorkspaceId()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Client.Tag(workspaceId, tagId)
 }
 
 func (c *ClientInWorkspace) UpdateTag(tagId string, tag *tagmanager.Tag) (*tagmanager.Tag, error) {
-	return c.Client.UpdateTag(c.Options.WorkspaceId, tagId, tag)
+	workspaceId, err := c.WorkspaceId()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Client.UpdateTag(workspaceId, tagId, tag)
 }
 
 func (c *ClientInWorkspace) DeleteTag(tagId string) error {
-	return c.Client.DeleteTag(c.Options.WorkspaceId, tagId)
+	workspaceId, err := c.WorkspaceId()
+	if err != nil {
+		return err
+	}
+
+	return c.Client.DeleteTag(workspaceId, tagId)
 }
 
 // Variable CRUD
 
 func (c *ClientInWorkspace) CreateVariable(variable *tagmanager.Variable) (*tagmanager.Variable, error) {
-	return c.Client.CreateVariable(c.Options.WorkspaceId, variable)
+	workspaceId, err := c.WorkspaceId()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Client.CreateVariable(workspaceId, variable)
 }
 
 func (c *ClientInWorkspace) ListVariables() ([]*tagmanager.Variable, error) {
-	return c.Client.ListVariables(c.Options.WorkspaceId)
+	workspaceId, err := c.WorkspaceId()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Client.ListVariables(workspaceId)
 }
 
 func (c *ClientInWorkspace) Variable(variableId string) (*tagmanager.Variable, error) {
-	return c.Client.Variable(c.Options.WorkspaceId, variableId)
+	workspaceId, err := c.WorkspaceId()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Client.Variable(workspaceId, variableId)
 }
 
 func (c *ClientInWorkspace) UpdateVariable(variableId string, variable *tagmanager.Variable) (*tagmanager.Variable, error) {
-	return c.Client.UpdateVariable(c.Options.WorkspaceId, variableId, variable)
+	workspaceId, err := c.WorkspaceId()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Client.UpdateVariable(workspaceId, variableId, variable)
 }
 
 func (c *ClientInWorkspace) DeleteVariable(variableId string) error {
-	return c.Client.DeleteVariable(c.Options.WorkspaceId, variableId)
+	workspaceId, err := c.WorkspaceId()
+	if err != nil {
+		return err
+	}
+
+	return c.Client.DeleteVariable(workspaceId, variableId)
+}
+
+// ListBuiltInVariables returns the built-in variables enabled in the
+// workspace.
+func (c *ClientInWorkspace) ListBuiltInVariables() ([]*tagmanager.BuiltInVariable, error) {
+	workspaceId, err := c.WorkspaceId()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Client.ListBuiltInVariables(workspaceId)
 }
 
 // Trigger CRUD
 
 func (c *ClientInWorkspace) CreateTrigger(trigger *tagmanager.Trigger) (*tagmanager.Trigger, error) {
-	return c.Client.CreateTrigger(c.Options.WorkspaceId, trigger)
+	workspaceId, err := c.WorkspaceId()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Client.CreateTrigger(workspaceId, trigger)
 }
 
 func (c *ClientInWorkspace) ListTriggers() ([]*tagmanager.Trigger, error) {
-	return c.Client.ListTriggers(c.Options.WorkspaceId)
+	workspaceId, err := c.WorkspaceId()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Client.ListTriggers(workspaceId)
 }
 
 func (c *ClientInWorkspace) Trigger(triggerId string) (*tagmanager.Trigger, error) {
-	return c.Client.Trigger(c.Options.WorkspaceId, triggerId)
+	workspaceId, err := c.WorkspaceId()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Client.Trigger(workspaceId, triggerId)
 }
 
 func (c *ClientInWorkspace) UpdateTrigger(triggerId string, trigger *tagmanager.Trigger) (*tagmanager.Trigger, error) {
-	return c.Client.UpdateTrigger(c.Options.WorkspaceId, triggerId, trigger)
+	workspaceId, err := c.WorkspaceId()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Client.UpdateTrigger(workspaceId, triggerId, trigger)
 }
 
 func (c *ClientInWorkspace) DeleteTrigger(triggerId string) error {
-	return c.Client.DeleteTrigger(c.Options.WorkspaceId, triggerId)
+	workspaceId, err := c.WorkspaceId()
+	if err != nil {
+		return err
+	}
+
+	return c.Client.DeleteTrigger(workspaceId, triggerId)
+}
+
+// Server client CRUD
+
+func (c *ClientInWorkspace) CreateServerClient(client *tagmanager.Client) (*tagmanager.Client, error) {
+	workspaceId, err := c.WorkspaceId()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Client.CreateServerClient(workspaceId, client)
+}
+
+func (c *ClientInWorkspace) ListServerClients() ([]*tagmanager.Client, error) {
+	workspaceId, err := c.WorkspaceId()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Client.ListServerClients(workspaceId)
+}
+
+func (c *ClientInWorkspace) ServerClient(clientId string) (*tagmanager.Client, error) {
+	workspaceId, err := c.WorkspaceId()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Client.ServerClient(workspaceId, clientId)
+}
+
+func (c *ClientInWorkspace) UpdateServerClient(clientId string, client *tagmanager.Client) (*tagmanager.Client, error) {
+	workspaceId, err := c.WorkspaceId()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Client.UpdateServerClient(workspaceId, clientId, client)
+}
+
+func (c *ClientInWorkspace) DeleteServerClient(clientId string) error {
+	workspaceId, err := c.WorkspaceId()
+	if err != nil {
+		return err
+	}
+
+	return c.Client.DeleteServerClient(workspaceId, clientId)
+}
+
+// Transformation CRUD
+
+func (c *ClientInWorkspace) CreateTransformation(transformation *tagmanager.Transformation) (*tagmanager.Transformation, error) {
+	workspaceId, err := c.WorkspaceId()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Client.CreateTransformation(workspaceId, transformation)
+}
+
+func (c *ClientInWorkspace) ListTransformations() ([]*tagmanager.Transformation, error) {
+	workspaceId, err := c.WorkspaceId()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Client.ListTransformations(workspaceId)
+}
+
+func (c *ClientInWorkspace) Transformation(transformationId string) (*tagmanager.Transformation, error) {
+	workspaceId, err := c.WorkspaceId()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Client.Transformation(workspaceId, transformationId)
+}
+
+func (c *ClientInWorkspace) UpdateTransformation(transformationId string, transformation *tagmanager.Transformation) (*tagmanager.Transformation, error) {
+	workspaceId, err := c.WorkspaceId()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Client.UpdateTransformation(workspaceId, transformationId, transformation)
+}
+
+func (c *ClientInWorkspace) DeleteTransformation(transformationId string) error {
+	workspaceId, err := c.WorkspaceId()
+	if err != nil {
+		return err
+	}
+
+	return c.Client.DeleteTransformation(workspaceId, transformationId)
+}
+
+// Zone CRUD
+
+func (c *ClientInWorkspace) CreateZone(zone *tagmanager.Zone) (*tagmanager.Zone, error) {
+	workspaceId, err := c.WorkspaceId()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Client.CreateZone(workspaceId, zone)
+}
+
+func (c *ClientInWorkspace) ListZones() ([]*tagmanager.Zone, error) {
+	workspaceId, err := c.WorkspaceId()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Client.ListZones(workspaceId)
+}
+
+func (c *ClientInWorkspace) Zone(zoneId string) (*tagmanager.Zone, error) {
+	workspaceId, err := c.WorkspaceId()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Client.Zone(workspaceId, zoneId)
+}
+
+func (c *ClientInWorkspace) UpdateZone(zoneId string, zone *tagmanager.Zone) (*tagmanager.Zone, error) {
+	workspaceId, err := c.WorkspaceId()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Client.UpdateZone(workspaceId, zoneId, zone)
+}
+
+func (c *ClientInWorkspace) DeleteZone(zoneId string) error {
+	workspaceId, err := c.WorkspaceId()
+	if err != nil {
+		return err
+	}
+
+	return c.Client.DeleteZone(workspaceId, zoneId)
+}
+
+// Template CRUD
+
+func (c *ClientInWorkspace) CreateTemplate(template *tagmanager.CustomTemplate) (*tagmanager.CustomTemplate, error) {
+	workspaceId, err := c.WorkspaceId()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Client.CreateTemplate(workspaceId, template)
+}
+
+func (c *ClientInWorkspace) ListTemplates() ([]*tagmanager.CustomTemplate, error) {
+	workspaceId, err := c.WorkspaceId()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Client.ListTemplates(workspaceId)
+}
+
+func (c *ClientInWorkspace) Template(templateId string) (*tagmanager.CustomTemplate, error) {
+	workspaceId, err := c.WorkspaceId()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Client.Template(workspaceId, templateId)
+}
+
+func (c *ClientInWorkspace) UpdateTemplate(templateId string, template *tagmanager.CustomTemplate) (*tagmanager.CustomTemplate, error) {
+	workspaceId, err := c.WorkspaceId()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Client.UpdateTemplate(workspaceId, templateId, template)
+}
+
+func (c *ClientInWorkspace) DeleteTemplate(templateId string) error {
+	workspaceId, err := c.WorkspaceId()
+	if err != nil {
+		return err
+	}
+
+	return c.Client.DeleteTemplate(workspaceId, templateId)
+}
+
+// Google tag config CRUD
+
+func (c *ClientInWorkspace) CreateGtagConfig(gtagConfig *tagmanager.GtagConfig) (*tagmanager.GtagConfig, error) {
+	workspaceId, err := c.WorkspaceId()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Client.CreateGtagConfig(workspaceId, gtagConfig)
+}
+
+func (c *ClientInWorkspace) ListGtagConfigs() ([]*tagmanager.GtagConfig, error) {
+	workspaceId, err := c.WorkspaceId()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Client.ListGtagConfigs(workspaceId)
+}
+
+func (c *ClientInWorkspace) GtagConfig(gtagConfigId string) (*tagmanager.GtagConfig, error) {
+	workspaceId, err := c.WorkspaceId()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Client.GtagConfig(workspaceId, gtagConfigId)
+}
+
+func (c *ClientInWorkspace) UpdateGtagConfig(gtagConfigId string, gtagConfig *tagmanager.GtagConfig) (*tagmanager.GtagConfig, error) {
+	workspaceId, err := c.WorkspaceId()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Client.UpdateGtagConfig(workspaceId, gtagConfigId, gtagConfig)
+}
+
+func (c *ClientInWorkspace) DeleteGtagConfig(gtagConfigId string) error {
+	workspaceId, err := c.WorkspaceId()
+	if err != nil {
+		return err
+	}
+
+	return c.Client.DeleteGtagConfig(workspaceId, gtagConfigId)
 }
@@ -4,19 +4,27 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/api/tagmanager/v2"
 )
 
 var testClientInWorkspaceOptions = &ClientInWorkspaceOptions{
-	WorkspaceName: "test-client-in-workspace",
-	ClientOptions: testClientOptions,
+	WorkspaceName:       "test-client-in-workspace",
+	ClientOptions:       testClientOptions,
+	AutoCreateWorkspace: true,
 }
 
 func TestNewClientInWorkspace(t *testing.T) {
+	skipWithoutCredentials(t)
 	client, err := NewClientInWorkspace(testClientInWorkspaceOptions)
+	require.NoError(t, err)
+	require.NotNil(t, client)
+
+	// The workspace is resolved on first use
+	workspaceId, err := client.WorkspaceId()
 	if err == nil {
 		defer func() {
-			err := client.DeleteWorkspace(client.Options.WorkspaceId)
+			err := client.DeleteWorkspace(workspaceId)
 			if err != nil {
 				t.Error(err)
 			}
@@ -24,8 +32,7 @@ func TestNewClientInWorkspace(t *testing.T) {
 	}
 
 	assert.NoError(t, err)
-	assert.NotNil(t, client)
-	assert.NotZero(t, client.Options.WorkspaceId)
+	assert.NotZero(t, workspaceId)
 
 	_, err = client.CreateTrigger(&tagmanager.Trigger{
 		Name:  "test-trigger-2",
@@ -37,3 +44,50 @@ func TestNewClientInWorkspace(t *testing.T) {
 	})
 	assert.NoError(t, err)
 }
+
+func TestClientInWorkspaceInWorkspace(t *testing.T) {
+	client := &ClientInWorkspace{
+		Client: &Client{Options: testClientOptions},
+		Options: &ClientInWorkspaceOptions{
+			ClientOptions: testClientOptions,
+			WorkspaceName: "default",
+			WorkspaceId:   "1",
+		},
+	}
+
+	// Fall back to the defaults
+	other, err := client.InWorkspace("", "", "")
+	assert.NoError(t, err)
+	assert.Equal(t, testClientOptions.ContainerId, other.Client.Options.ContainerId)
+	assert.Equal(t, "1", other.Options.WorkspaceId)
+
+	// Override the workspace only
+	other, err = client.InWorkspace("", "", "2")
+	assert.NoError(t, err)
+	assert.Equal(t, testClientOptions.AccountId, other.Client.Options.AccountId)
+	assert.Equal(t, "2", other.Options.WorkspaceId)
+
+	// Override the container and workspace
+	other, err = client.InWorkspace("", "42", "3")
+	assert.NoError(t, err)
+	assert.Equal(t, "42", other.Client.Options.ContainerId)
+	assert.Equal(t, "3", other.Options.WorkspaceId)
+	assert.Equal(t, testClientOptions.ContainerId, client.Client.Options.ContainerId)
+
+	// The default workspace does not exist in another container
+	_, err = client.InWorkspace("", "42", "")
+	assert.Equal(t, ErrWorkspaceRequired, err)
+}
+
+func TestClientInWorkspaceNoWorkspace(t *testing.T) {
+	client := &ClientInWorkspace{
+		Client:  &Client{Options: testClientOptions},
+		Options: &ClientInWorkspaceOptions{ClientOptions: testClientOptions},
+	}
+
+	_, err := client.WorkspaceId()
+	assert.Equal(t, ErrNoWorkspace, err)
+
+	_, err = client.Tag("1")
+	assert.Equal(t, ErrNoWorkspace, err)
+}
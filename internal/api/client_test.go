@@ -1,10 +1,12 @@
 package api
 
 import (
+	"os"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/api/tagmanager/v2"
 )
 
@@ -14,10 +16,20 @@ var testClientOptions = &ClientOptions{
 	ContainerId:    "119458552",
 }
 
+// skipWithoutCredentials skips tests against the API when the credentials
+// file of the test account is missing.
+func skipWithoutCredentials(t *testing.T) {
+	if _, err := os.Stat(testClientOptions.CredentialFile); os.IsNotExist(err) {
+		t.Skipf("%s is missing", testClientOptions.CredentialFile)
+	}
+}
+
 func newTestClient(t *testing.T) *Client {
+	skipWithoutCredentials(t)
 	client, err := NewClient(testClientOptions)
 
-	assert.Nil(t, err)
+	require.NoError(t, err)
+	require.NotNil(t, client)
 	return client
 }
 
@@ -30,6 +42,166 @@ func currentTimeString() string {
 	return time.Now().Format("2006-01-02-15-04-05")
 }
 
+func TestClientContainerCRUD(t *testing.T) {
+	client := newTestClient(t)
+
+	// Create container
+	container, err := client.CreateContainer(&tagmanager.Container{
+		Name:         "test-container-CRUD-" + currentTimeString(),
+		UsageContext: []string{"web"},
+		Notes:        "created by unit test",
+	})
+	assert.NoError(t, err)
+	assert.NotNil(t, container)
+	assert.NotZero(t, container.PublicId)
+
+	// Get container
+	fetched, err := client.Container(container.ContainerId)
+	assert.NoError(t, err)
+	assert.Equal(t, container.Name, fetched.Name)
+
+	// List containers
+	list, err := client.ListContainers()
+	assert.NoError(t, err)
+	assert.Greater(t, len(list), 0)
+
+	// Update container
+	updated, err := client.UpdateContainer(container.ContainerId, &tagmanager.Container{
+		Name:         "updated-container-" + currentTimeString(),
+		UsageContext: []string{"web"},
+		DomainName:   []string{"example.com"},
+		Notes:        "updated by unit test",
+	})
+	assert.NoError(t, err)
+	assert.Contains(t, updated.Name, "updated-container")
+
+	// Delete container
+	err = client.DeleteContainer(container.ContainerId)
+	assert.NoError(t, err)
+
+	// Get nonexisting container
+	container, err = client.Container(container.ContainerId)
+	assert.Equal(t, ErrNotExist, err)
+	assert.Nil(t, container)
+}
+
+func TestClientUserPermissionCRUD(t *testing.T) {
+	client := newTestClient(t)
+
+	// Create user permission
+	permission, err := client.CreateUserPermission(&tagmanager.UserPermission{
+		EmailAddress:  "test-user-permission@example.com",
+		AccountAccess: &tagmanager.AccountAccess{Permission: "user"},
+		ContainerAccess: []*tagmanager.ContainerAccess{
+			{ContainerId: testClientOptions.ContainerId, Permission: "read"},
+		},
+	})
+	assert.NoError(t, err)
+	assert.NotNil(t, permission)
+	id := UserPermissionId(permission)
+	assert.NotZero(t, id)
+
+	// Get user permission
+	fetched, err := client.UserPermission(id)
+	assert.NoError(t, err)
+	assert.Equal(t, permission.EmailAddress, fetched.EmailAddress)
+
+	// List user permissions
+	list, err := client.ListUserPermissions()
+	assert.NoError(t, err)
+	assert.Greater(t, len(list), 0)
+
+	// Update user permission
+	updated, err := client.UpdateUserPermission(id, &tagmanager.UserPermission{
+		EmailAddress:  "test-user-permission@example.com",
+		AccountAccess: &tagmanager.AccountAccess{Permission: "user"},
+		ContainerAccess: []*tagmanager.ContainerAccess{
+			{ContainerId: testClientOptions.ContainerId, Permission: "publish"},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "publish", updated.ContainerAccess[0].Permission)
+
+	// Delete user permission
+	err = client.DeleteUserPermission(id)
+	assert.NoError(t, err)
+
+	// Get nonexisting user permission
+	permission, err = client.UserPermission(id)
+	assert.Equal(t, ErrNotExist, err)
+	assert.Nil(t, permission)
+}
+
+func TestUserPermissionId(t *testing.T) {
+	id := UserPermissionId(&tagmanager.UserPermission{Path: "accounts/6105084028/user_permissions/1234"})
+	assert.Equal(t, "1234", id)
+}
+
+func TestClientEnvironmentCRUD(t *testing.T) {
+	client := newTestClient(t)
+
+	// Create environment
+	env, err := client.CreateEnvironment(&tagmanager.Environment{
+		Name:        "test-environment-CRUD-" + currentTimeString(),
+		Description: "created by unit test",
+		Url:         "https://staging.example.com",
+	})
+	assert.NoError(t, err)
+	assert.NotNil(t, env)
+	assert.NotZero(t, env.AuthorizationCode)
+
+	// Get environment
+	fetched, err := client.Environment(env.EnvironmentId)
+	assert.NoError(t, err)
+	assert.Equal(t, env.Name, fetched.Name)
+
+	// List environments
+	list, err := client.ListEnvironments()
+	assert.NoError(t, err)
+	assert.Greater(t, len(list), 0)
+
+	// Update environment
+	updated, err := client.UpdateEnvironment(env.EnvironmentId, &tagmanager.Environment{
+		Name:        "updated-environment-" + currentTimeString(),
+		Description: "updated by unit test",
+		EnableDebug: true,
+	})
+	assert.NoError(t, err)
+	assert.Contains(t, updated.Name, "updated-environment")
+
+	// Reauthorize environment
+	reauthorized, err := client.ReauthorizeEnvironment(env.EnvironmentId)
+	assert.NoError(t, err)
+	assert.NotEqual(t, env.AuthorizationCode, reauthorized.AuthorizationCode)
+
+	// Delete environment
+	err = client.DeleteEnvironment(env.EnvironmentId)
+	assert.NoError(t, err)
+
+	// Get nonexisting environment
+	env, err = client.Environment(env.EnvironmentId)
+	assert.Equal(t, ErrNotExist, err)
+	assert.Nil(t, env)
+}
+
+func TestClientListDestinations(t *testing.T) {
+	client := newTestClient(t)
+
+	list, err := client.ListDestinations()
+	assert.NoError(t, err)
+
+	for _, destination := range list {
+		fetched, err := client.Destination(destination.DestinationLinkId)
+		assert.NoError(t, err)
+		assert.Equal(t, destination.DestinationId, fetched.DestinationId)
+	}
+
+	// Get nonexisting destination
+	destination, err := client.Destination("0")
+	assert.Equal(t, ErrNotExist, err)
+	assert.Nil(t, destination)
+}
+
 func TestClientWorkSpaceCRUD(t *testing.T) {
 	client := newTestClient(t)
 
@@ -214,3 +386,255 @@ func TestClientTriggerCRUD(t *testing.T) {
 	err = client.DeleteTrigger(ws.WorkspaceId, trigger.TriggerId)
 	assert.NoError(t, err)
 }
+
+// newTestServerContainer creates a server-side container with a workspace and
+// returns a client bound to it, together with the ID of the workspace.
+func newTestServerContainer(t *testing.T, name string) (*Client, string) {
+	client := newTestClient(t)
+	container, err := client.CreateContainer(&tagmanager.Container{
+		Name:         name + "-" + currentTimeString(),
+		UsageContext: []string{"server"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.DeleteContainer(container.ContainerId) })
+
+	client = client.InContainer("", container.ContainerId)
+	ws, err := client.CreateWorkspace(&tagmanager.Workspace{
+		Name:        name,
+		Description: "created by unit test",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return client, ws.WorkspaceId
+}
+
+func TestClientServerClientCRUD(t *testing.T) {
+	client, workspaceId := newTestServerContainer(t, "test-clients-CRUD")
+
+	// Create client
+	serverClient, err := client.CreateServerClient(workspaceId, &tagmanager.Client{
+		Name:     "test-client-1",
+		Type:     "gaaw_client",
+		Priority: 1,
+		Parameter: []*tagmanager.Parameter{
+			{Key: "activateDefaultPaths", Type: "boolean", Value: "true"},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "test-client-1", serverClient.Name)
+
+	// Get client
+	serverClient, err = client.ServerClient(workspaceId, serverClient.ClientId)
+	assert.NoError(t, err)
+	assert.Equal(t, "test-client-1", serverClient.Name)
+
+	// Update client
+	serverClient, err = client.UpdateServerClient(workspaceId, serverClient.ClientId, &tagmanager.Client{
+		Name:     "test-client-2",
+		Type:     "gaaw_client",
+		Priority: 2,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), serverClient.Priority)
+
+	// Delete client
+	err = client.DeleteServerClient(workspaceId, serverClient.ClientId)
+	assert.NoError(t, err)
+}
+
+func TestClientTransformationCRUD(t *testing.T) {
+	client, workspaceId := newTestServerContainer(t, "test-transformations-CRUD")
+
+	// Create transformation
+	transformation, err := client.CreateTransformation(workspaceId, &tagmanager.Transformation{
+		Name: "test-transformation-1",
+		Type: "tf_exclude_params",
+		Parameter: []*tagmanager.Parameter{
+			{Key: "excludeList", Type: "list", List: []*tagmanager.Parameter{
+				{Type: "map", Map: []*tagmanager.Parameter{
+					{Key: "paramName", Type: "template", Value: "ip_override"},
+				}},
+			}},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "test-transformation-1", transformation.Name)
+
+	// Get transformation
+	transformation, err = client.Transformation(workspaceId, transformation.TransformationId)
+	assert.NoError(t, err)
+	assert.Equal(t, "test-transformation-1", transformation.Name)
+
+	// Update transformation
+	transformation, err = client.UpdateTransformation(workspaceId, transformation.TransformationId, &tagmanager.Transformation{
+		Name:  "test-transformation-2",
+		Type:  "tf_exclude_params",
+		Notes: "updated by unit test",
+	})
+	assert.NoError(t, err)
+
+	// Delete transformation
+	err = client.DeleteTransformation(workspaceId, transformation.TransformationId)
+	assert.NoError(t, err)
+}
+
+func TestClientZoneCRUD(t *testing.T) {
+	client := newTestClient(t)
+	ws, err := client.CreateWorkspace(&tagmanager.Workspace{
+		Name:        "test-zones-CRUD-" + currentTimeString(),
+		Description: "created by unit test",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.DeleteWorkspace(ws.WorkspaceId)
+
+	// Create zone
+	zone, err := client.CreateZone(ws.WorkspaceId, &tagmanager.Zone{
+		Name:  "test-zone-1",
+		Notes: "created by unit test",
+		Boundary: &tagmanager.ZoneBoundary{
+			Condition: []*tagmanager.Condition{
+				{
+					Type: "contains",
+					Parameter: []*tagmanager.Parameter{
+						{Key: "arg0", Value: "{{Page URL}}", Type: "template"},
+						{Key: "arg1", Value: "/partner", Type: "template"},
+					},
+				},
+			},
+		},
+		TypeRestriction: &tagmanager.ZoneTypeRestriction{
+			Enable:            true,
+			WhitelistedTypeId: []string{"google"},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "test-zone-1", zone.Name)
+
+	// Get zone
+	zone, err = client.Zone(ws.WorkspaceId, zone.ZoneId)
+	assert.NoError(t, err)
+	assert.Equal(t, "test-zone-1", zone.Name)
+	assert.Equal(t, "/partner", zone.Boundary.Condition[0].Parameter[1].Value)
+
+	// Update zone
+	zone, err = client.UpdateZone(ws.WorkspaceId, zone.ZoneId, &tagmanager.Zone{
+		Name:  "test-zone-2",
+		Notes: "updated by unit test",
+	})
+	assert.NoError(t, err)
+
+	// Delete zone
+	err = client.DeleteZone(ws.WorkspaceId, zone.ZoneId)
+	assert.NoError(t, err)
+}
+
+const testTemplateData = `___INFO___
+
+{
+  "type": "TAG",
+  "id": "cvt_temp_public_id",
+  "version": 1,
+  "securityGroups": [],
+  "displayName": "test template",
+  "brand": {
+    "id": "brand_dummy",
+    "displayName": ""
+  },
+  "description": "created by unit test",
+  "containerContexts": [
+    "WEB"
+  ]
+}
+
+
+___TEMPLATE_PARAMETERS___
+
+[]
+
+
+___SANDBOXED_JS_FOR_WEB_TEMPLATE___
+
+data.gtmOnSuccess();
+`
+
+func TestClientTemplateCRUD(t *testing.T) {
+	client := newTestClient(t)
+	ws, err := client.CreateWorkspace(&tagmanager.Workspace{
+		Name:        "test-templates-CRUD-" + currentTimeString(),
+		Description: "created by unit test",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.DeleteWorkspace(ws.WorkspaceId)
+
+	// Create template
+	template, err := client.CreateTemplate(ws.WorkspaceId, &tagmanager.CustomTemplate{
+		Name:         "test-template-1",
+		TemplateData: testTemplateData,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "test-template-1", template.Name)
+
+	// Get template
+	template, err = client.Template(ws.WorkspaceId, template.TemplateId)
+	assert.NoError(t, err)
+	assert.Equal(t, "test-template-1", template.Name)
+
+	// Update template
+	template, err = client.UpdateTemplate(ws.WorkspaceId, template.TemplateId, &tagmanager.CustomTemplate{
+		Name:         "test-template-2",
+		TemplateData: testTemplateData,
+	})
+	assert.NoError(t, err)
+
+	// Delete template
+	err = client.DeleteTemplate(ws.WorkspaceId, template.TemplateId)
+	assert.NoError(t, err)
+}
+
+func TestClientGtagConfigCRUD(t *testing.T) {
+	client := newTestClient(t)
+	ws, err := client.CreateWorkspace(&tagmanager.Workspace{
+		Name:        "test-gtag-config-CRUD-" + currentTimeString(),
+		Description: "created by unit test",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.DeleteWorkspace(ws.WorkspaceId)
+
+	// Create Google tag config
+	gtagConfig, err := client.CreateGtagConfig(ws.WorkspaceId, &tagmanager.GtagConfig{
+		Type: "googtag",
+		Parameter: []*tagmanager.Parameter{
+			{Key: "tagId", Type: "template", Value: "G-A2ABC2ABCD"},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "googtag", gtagConfig.Type)
+
+	// Get Google tag config
+	gtagConfig, err = client.GtagConfig(ws.WorkspaceId, gtagConfig.GtagConfigId)
+	assert.NoError(t, err)
+	assert.Equal(t, "G-A2ABC2ABCD", gtagConfig.Parameter[0].Value)
+
+	// Update Google tag config
+	gtagConfig, err = client.UpdateGtagConfig(ws.WorkspaceId, gtagConfig.GtagConfigId, &tagmanager.GtagConfig{
+		Type: "googtag",
+		Parameter: []*tagmanager.Parameter{
+			{Key: "tagId", Type: "template", Value: "G-B2ABC2ABCD"},
+		},
+	})
+	assert.NoError(t, err)
+
+	// Delete Google tag config
+	err = client.DeleteGtagConfig(ws.WorkspaceId, gtagConfig.GtagConfigId)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,109 @@
+package api
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// logSubsystem is the tflog subsystem of the API calls. It logs at the level
+// of the provider unless TF_LOG_PROVIDER_GTM_API is set.
+const logSubsystem = "api"
+
+// Fields of the API call logs, which can be used to filter them.
+const (
+	logFieldMethod       = "gtm_api_method"
+	logFieldPath         = "gtm_api_path"
+	logFieldStatusCode   = "gtm_api_status_code"
+	logFieldDurationMs   = "gtm_api_duration_ms"
+	logFieldError        = "gtm_api_error"
+	logFieldRequestBody  = "gtm_api_request_body"
+	logFieldResponseBody = "gtm_api_response_body"
+)
+
+// secrets match credentials and tokens in request and response bodies.
+var secrets = []struct {
+	pattern     *regexp.Regexp
+	replacement string
+}{
+	{regexp.MustCompile(`("(?:access_token|refresh_token|id_token|client_secret|private_key|private_key_id|password|assertion)"\s*:\s*)"(?:[^"\\]|\\.)*"`), `${1}"***"`},
+	{regexp.MustCompile(`(?i)(bearer\s+)[A-Za-z0-9._~+/=-]+`), `${1}***`},
+}
+
+// redact replaces the credentials and tokens in s.
+func redact(s string) string {
+	for _, secret := range secrets {
+		s = secret.pattern.ReplaceAllString(s, secret.replacement)
+	}
+
+	return s
+}
+
+// loggingTransport logs the API calls with the loggers of the context of
+// their request, i.e. of the operation that makes them.
+type loggingTransport struct {
+	next http.RoundTripper
+}
+
+func (t loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := tflog.NewSubsystem(req.Context(), logSubsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER_GTM", "API"), tflog.WithRootFields())
+	ctx = tflog.SubsystemSetField(ctx, logSubsystem, logFieldMethod, req.Method)
+	ctx = tflog.SubsystemSetField(ctx, logSubsystem, logFieldPath, req.URL.Path)
+
+	tflog.SubsystemTrace(ctx, logSubsystem, "Sending API request", map[string]interface{}{
+		logFieldRequestBody: requestBody(req),
+	})
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	fields := map[string]interface{}{
+		logFieldDurationMs: time.Since(start).Milliseconds(),
+	}
+
+	if err != nil {
+		fields[logFieldError] = err.Error()
+		tflog.SubsystemDebug(ctx, logSubsystem, "API request failed", fields)
+		return nil, err
+	}
+	fields[logFieldStatusCode] = resp.StatusCode
+
+	tflog.SubsystemDebug(ctx, logSubsystem, "API request finished", fields)
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	tflog.SubsystemTrace(ctx, logSubsystem, "Received API response", map[string]interface{}{
+		logFieldStatusCode:   resp.StatusCode,
+		logFieldResponseBody: redact(string(body)),
+	})
+
+	return resp, nil
+}
+
+// requestBody returns the redacted body of req without consuming it.
+func requestBody(req *http.Request) string {
+	if req.Body == nil || req.GetBody == nil {
+		return ""
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+
+	b, err := io.ReadAll(body)
+	if err != nil {
+		return ""
+	}
+
+	return redact(string(b))
+}
@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"context"
+	"terraform-provider-google-tag-manager/internal/api"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/api/tagmanager/v2"
+)
+
+var (
+	_ datasource.DataSourceWithConfigure = &containerDataSource{}
+)
+
+func NewContainerDataSource() datasource.DataSource {
+	return &containerDataSource{}
+}
+
+type containerDataSource struct {
+	client *api.ClientInWorkspace
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *containerDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(*api.ClientInWorkspace)
+}
+
+// Metadata returns the data source type name.
+func (d *containerDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_container"
+}
+
+// Schema defines the schema for the data source.
+func (d *containerDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Looks up a container by name or public ID. Exactly one of them must be set.",
+		Attributes: map[string]schema.Attribute{
+			"account_id": schema.StringAttribute{
+				Description: "GTM Account ID. Defaults to the account_id of the provider.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the container.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"public_id": schema.StringAttribute{
+				Description: "The public ID of the container, e.g. GTM-XXXX.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"id": schema.StringAttribute{
+				Description: "The ID of the container.",
+				Computed:    true,
+			},
+			"usage_context": schema.ListAttribute{
+				Description: "The usage contexts of the container.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"domain_name": schema.ListAttribute{
+				Description: "The domain names associated with the container.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"notes": schema.StringAttribute{
+				Description: "The notes of the container.",
+				Computed:    true,
+			},
+			"tag_ids": schema.ListAttribute{
+				Description: "All tag IDs that refer to the container.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *containerDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config resourceContainerModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Name.IsNull() == config.PublicId.IsNull() {
+		resp.Diagnostics.AddError("Invalid Container Lookup", "Exactly one of name and public_id must be set.")
+		return
+	}
+
+	client := d.client.WithContext(ctx).InContainer(config.AccountId.ValueString(), "")
+	containers, err := client.ListContainers()
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Containers", err.Error())
+		return
+	}
+
+	var found *tagmanager.Container
+	for _, container := range containers {
+		if (!config.Name.IsNull() && container.Name == config.Name.ValueString()) ||
+			(!config.PublicId.IsNull() && container.PublicId == config.PublicId.ValueString()) {
+			found = container
+			break
+		}
+	}
+
+	if found == nil {
+		resp.Diagnostics.AddError("Container Not Found", "No container matches the given name or public_id.")
+		return
+	}
+
+	diags = resp.State.Set(ctx, toResourceContainer(found, resourceContainerModel{}))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
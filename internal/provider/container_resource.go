@@ -0,0 +1,206 @@
+package provider
+
+import (
+	"context"
+	"terraform-provider-google-tag-manager/internal/api"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/api/tagmanager/v2"
+)
+
+var (
+	_ resource.ResourceWithConfigure = &containerResource{}
+)
+
+func NewContainerResource() resource.Resource {
+	return &containerResource{}
+}
+
+type containerResource struct {
+	client *api.ClientInWorkspace
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *containerResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(*api.ClientInWorkspace)
+}
+
+// Metadata returns the resource type name.
+func (r *containerResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_container"
+}
+
+var containerResourceSchemaAttributes = map[string]schema.Attribute{
+	"account_id": accountIdSchema,
+	"name": schema.StringAttribute{
+		Description: "The name of the container.",
+		Required:    true,
+	},
+	"usage_context": schema.ListAttribute{
+		Description: "The usage contexts of the container: web, android, ios, server or amp.",
+		Required:    true,
+		ElementType: types.StringType,
+	},
+	"domain_name": schema.ListAttribute{
+		Description: "The domain names associated with the container.",
+		Optional:    true,
+		ElementType: types.StringType,
+	},
+	"notes": schema.StringAttribute{
+		Description: "The notes of the container.",
+		Optional:    true,
+	},
+	"id": schema.StringAttribute{
+		Description:   "The ID of the container.",
+		Computed:      true,
+		PlanModifiers: idPlanModifiers,
+	},
+	"public_id": schema.StringAttribute{
+		Description: "The public ID of the container, e.g. GTM-XXXX.",
+		Computed:    true,
+	},
+	"tag_ids": schema.ListAttribute{
+		Description: "All tag IDs that refer to the container.",
+		Computed:    true,
+		ElementType: types.StringType,
+	},
+}
+
+// Schema defines the schema for the resource.
+func (r *containerResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{Attributes: containerResourceSchemaAttributes}
+}
+
+type resourceContainerModel struct {
+	AccountId    types.String   `tfsdk:"account_id"`
+	Name         types.String   `tfsdk:"name"`
+	UsageContext []types.String `tfsdk:"usage_context"`
+	DomainName   []types.String `tfsdk:"domain_name"`
+	Notes        types.String   `tfsdk:"notes"`
+	Id           types.String   `tfsdk:"id"`
+	PublicId     types.String   `tfsdk:"public_id"`
+	TagIds       []types.String `tfsdk:"tag_ids"`
+}
+
+func toResourceContainer(container *tagmanager.Container, prior resourceContainerModel) resourceContainerModel {
+	return resourceContainerModel{
+		AccountId:    types.StringValue(container.AccountId),
+		Name:         types.StringValue(container.Name),
+		UsageContext: priorStringArray(container.UsageContext, prior.UsageContext),
+		DomainName:   priorStringArray(container.DomainName, prior.DomainName),
+		Notes:        priorStringValue(container.Notes, prior.Notes),
+		Id:           types.StringValue(container.ContainerId),
+		PublicId:     types.StringValue(container.PublicId),
+		TagIds:       toResourceStringArray(container.TagIds),
+	}
+}
+
+func toApiContainer(resource resourceContainerModel) *tagmanager.Container {
+	return &tagmanager.Container{
+		Name:         resource.Name.ValueString(),
+		UsageContext: unwrapStringArray(resource.UsageContext),
+		DomainName:   unwrapStringArray(resource.DomainName),
+		Notes:        resource.Notes.ValueString(),
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *containerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan resourceContainerModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.client.WithContext(ctx).InContainer(plan.AccountId.ValueString(), "")
+	container, err := client.CreateContainer(toApiContainer(plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Creating Container", err.Error())
+		return
+	}
+
+	diags = resp.State.Set(ctx, toResourceContainer(container, plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *containerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state resourceContainerModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.client.WithContext(ctx).InContainer(state.AccountId.ValueString(), "")
+	container, err := client.Container(state.Id.ValueString())
+	if err == api.ErrNotExist {
+		resp.State.RemoveResource(ctx)
+		return
+	} else if err != nil {
+		resp.Diagnostics.AddError("Error Reading Container", err.Error())
+		return
+	}
+
+	diags = resp.State.Set(ctx, toResourceContainer(container, state))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *containerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state resourceContainerModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.client.WithContext(ctx).InContainer(state.AccountId.ValueString(), "")
+	container, err := client.UpdateContainer(state.Id.ValueString(), toApiContainer(plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Updating Container", err.Error())
+		return
+	}
+
+	diags = resp.State.Set(ctx, toResourceContainer(container, plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *containerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state resourceContainerModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.client.WithContext(ctx).InContainer(state.AccountId.ValueString(), "")
+	err := client.DeleteContainer(state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error Deleting Container", err.Error())
+		return
+	}
+}
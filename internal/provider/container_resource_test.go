@@ -0,0 +1,29 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/tagmanager/v2"
+)
+
+func TestToResourceContainerKeepsEmptyValues(t *testing.T) {
+	container := &tagmanager.Container{
+		AccountId:    "6105084028",
+		ContainerId:  "119458552",
+		Name:         "test-container",
+		UsageContext: []string{"web"},
+	}
+
+	state := toResourceContainer(container, resourceContainerModel{
+		DomainName: []types.String{},
+		Notes:      types.StringValue(""),
+	})
+	assert.Equal(t, []types.String{}, state.DomainName)
+	assert.Equal(t, types.StringValue(""), state.Notes)
+
+	state = toResourceContainer(container, resourceContainerModel{})
+	assert.Nil(t, state.DomainName)
+	assert.True(t, state.Notes.IsNull())
+}
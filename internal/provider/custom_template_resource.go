@@ -0,0 +1,366 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"terraform-provider-google-tag-manager/internal/api"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/api/tagmanager/v2"
+)
+
+var (
+	_ resource.ResourceWithConfigure  = &customTemplateResource{}
+	_ resource.ResourceWithModifyPlan = &customTemplateResource{}
+)
+
+func NewCustomTemplateResource() resource.Resource {
+	return &customTemplateResource{}
+}
+
+type customTemplateResource struct {
+	client *api.ClientInWorkspace
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *customTemplateResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(*api.ClientInWorkspace)
+}
+
+// Metadata returns the resource type name.
+func (r *customTemplateResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_custom_template"
+}
+
+var customTemplateResourceSchemaAttributes = map[string]schema.Attribute{
+	"account_id":   accountIdSchema,
+	"container_id": containerIdSchema,
+	"workspace_id": workspaceIdSchema,
+	"name": schema.StringAttribute{
+		Description: "The name of the template.",
+		Required:    true,
+	},
+	"template_data": schema.StringAttribute{
+		Description: "The template in the .tpl text format, e.g. file(\"template.tpl\").",
+		Required:    true,
+	},
+	"gallery_reference": schema.SingleNestedAttribute{
+		Description: "The Community Template Gallery entry the template comes from. To update to a newer gallery version, change version together with template_data. The update fails if the API keeps the prior version, which the state then records, and the template must be replaced.",
+		Optional:    true,
+		Attributes: map[string]schema.Attribute{
+			"host": schema.StringAttribute{
+				Description:   "The host of the gallery template, e.g. github.com.",
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"owner": schema.StringAttribute{
+				Description: "The owner of the gallery template.",
+				Required:    true,
+			},
+			"repository": schema.StringAttribute{
+				Description: "The repository of the gallery template.",
+				Required:    true,
+			},
+			"version": schema.StringAttribute{
+				Description: "The version of the gallery template.",
+				Required:    true,
+			},
+			"signature": schema.StringAttribute{
+				Description:   "The signature of the gallery template.",
+				Optional:      true,
+				Computed:      true,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+			},
+			"is_modified": schema.BoolAttribute{
+				Description:   "Whether the template was modified after it was imported from the gallery.",
+				Computed:      true,
+				PlanModifiers: []planmodifier.Bool{boolplanmodifier.UseStateForUnknown()},
+			},
+		},
+	},
+	"id": schema.StringAttribute{
+		Description:   "The ID of the template.",
+		Computed:      true,
+		PlanModifiers: idPlanModifiers,
+	},
+	"type": schema.StringAttribute{
+		Description:   "The type identifier of the template, to be used as the type of tags and variables.",
+		Computed:      true,
+		PlanModifiers: idPlanModifiers,
+	},
+}
+
+// Schema defines the schema for the resource.
+func (r *customTemplateResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{Attributes: customTemplateResourceSchemaAttributes}
+}
+
+// ModifyPlan leaves the gallery reference attributes that the API recomputes
+// unknown when the gallery entry or the template data change. Otherwise they
+// keep their prior values.
+func (r *customTemplateResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state, config resourceCustomTemplateModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || plan.GalleryReference == nil || state.GalleryReference == nil {
+		return
+	}
+
+	ref, prior := plan.GalleryReference, state.GalleryReference
+	entryChanged := !ref.Owner.Equal(prior.Owner) || !ref.Repository.Equal(prior.Repository) || !ref.Version.Equal(prior.Version)
+	if entryChanged {
+		if config.GalleryReference.Host.IsNull() {
+			ref.Host = types.StringUnknown()
+		}
+		if config.GalleryReference.Signature.IsNull() {
+			ref.Signature = types.StringUnknown()
+		}
+	}
+	if entryChanged || !templateDataEqual(plan.TemplateData, state.TemplateData) {
+		ref.IsModified = types.BoolUnknown()
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, plan)...)
+}
+
+type resourceGalleryReferenceModel struct {
+	Host       types.String `tfsdk:"host"`
+	Owner      types.String `tfsdk:"owner"`
+	Repository types.String `tfsdk:"repository"`
+	Version    types.String `tfsdk:"version"`
+	Signature  types.String `tfsdk:"signature"`
+	IsModified types.Bool   `tfsdk:"is_modified"`
+}
+
+type resourceCustomTemplateModel struct {
+	AccountId        types.String                   `tfsdk:"account_id"`
+	ContainerId      types.String                   `tfsdk:"container_id"`
+	WorkspaceId      types.String                   `tfsdk:"workspace_id"`
+	Name             types.String                   `tfsdk:"name"`
+	TemplateData     types.String                   `tfsdk:"template_data"`
+	GalleryReference *resourceGalleryReferenceModel `tfsdk:"gallery_reference"`
+	Id               types.String                   `tfsdk:"id"`
+	Type             types.String                   `tfsdk:"type"`
+}
+
+// customTemplateType returns the type that tags and variables based on the
+// template refer to.
+func customTemplateType(template *tagmanager.CustomTemplate) string {
+	return "cvt_" + template.ContainerId + "_" + template.TemplateId
+}
+
+// templateDataEqual returns whether two template data are the same apart
+// from line endings and trailing whitespace, which the API normalizes.
+func templateDataEqual(a types.String, b types.String) bool {
+	if a.IsUnknown() || b.IsUnknown() || a.IsNull() != b.IsNull() {
+		return false
+	}
+
+	normalize := func(s string) string {
+		return strings.TrimRight(strings.ReplaceAll(s, "\r\n", "\n"), " \t\r\n")
+	}
+	return normalize(a.ValueString()) == normalize(b.ValueString())
+}
+
+// toResourceCustomTemplate converts a template of the API. The template data
+// of prior is kept when the API returns it normalized.
+func toResourceCustomTemplate(template *tagmanager.CustomTemplate, prior resourceCustomTemplateModel) resourceCustomTemplateModel {
+	var galleryReference *resourceGalleryReferenceModel
+	if ref := template.GalleryReference; ref != nil {
+		galleryReference = &resourceGalleryReferenceModel{
+			Host:       types.StringValue(ref.Host),
+			Owner:      types.StringValue(ref.Owner),
+			Repository: types.StringValue(ref.Repository),
+			Version:    types.StringValue(ref.Version),
+			Signature:  types.StringValue(ref.Signature),
+			IsModified: types.BoolValue(ref.IsModified),
+		}
+	}
+
+	templateData := types.StringValue(template.TemplateData)
+	if templateDataEqual(templateData, prior.TemplateData) {
+		templateData = prior.TemplateData
+	}
+
+	return resourceCustomTemplateModel{
+		AccountId:        types.StringValue(template.AccountId),
+		ContainerId:      types.StringValue(template.ContainerId),
+		WorkspaceId:      types.StringValue(template.WorkspaceId),
+		Name:             types.StringValue(template.Name),
+		TemplateData:     templateData,
+		GalleryReference: galleryReference,
+		Id:               types.StringValue(template.TemplateId),
+		Type:             types.StringValue(customTemplateType(template)),
+	}
+}
+
+func toApiCustomTemplate(resource resourceCustomTemplateModel) *tagmanager.CustomTemplate {
+	var galleryReference *tagmanager.GalleryReference
+	if ref := resource.GalleryReference; ref != nil {
+		galleryReference = &tagmanager.GalleryReference{
+			Host:       ref.Host.ValueString(),
+			Owner:      ref.Owner.ValueString(),
+			Repository: ref.Repository.ValueString(),
+			Version:    ref.Version.ValueString(),
+			Signature:  ref.Signature.ValueString(),
+		}
+	}
+
+	return &tagmanager.CustomTemplate{
+		Name:             resource.Name.ValueString(),
+		TemplateData:     resource.TemplateData.ValueString(),
+		GalleryReference: galleryReference,
+		TemplateId:       resource.Id.ValueString(),
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *customTemplateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan resourceCustomTemplateModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := clientInWorkspace(ctx, r.client, plan.AccountId, plan.ContainerId, plan.WorkspaceId)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Creating Custom Template", err.Error())
+		return
+	}
+
+	template, err := client.CreateTemplate(toApiCustomTemplate(plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Creating Custom Template", err.Error())
+		return
+	}
+
+	diags = resp.State.Set(ctx, toResourceCustomTemplate(template, plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *customTemplateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state resourceCustomTemplateModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := clientInWorkspace(ctx, r.client, state.AccountId, state.ContainerId, state.WorkspaceId)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Custom Template", err.Error())
+		return
+	}
+
+	template, err := client.Template(state.Id.ValueString())
+	if err == api.ErrNotExist {
+		resp.State.RemoveResource(ctx)
+		return
+	} else if err != nil {
+		resp.Diagnostics.AddError("Error Reading Custom Template", err.Error())
+		return
+	}
+
+	diags = resp.State.Set(ctx, toResourceCustomTemplate(template, state))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *customTemplateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state resourceCustomTemplateModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := clientInWorkspace(ctx, r.client, state.AccountId, state.ContainerId, state.WorkspaceId)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Updating Custom Template", err.Error())
+		return
+	}
+
+	template, err := client.UpdateTemplate(state.Id.ValueString(), toApiCustomTemplate(plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Updating Custom Template", err.Error())
+		return
+	}
+
+	// The template is updated, so its state is saved, with the version the
+	// API kept, even when the planned version was not applied.
+	diags = resp.State.Set(ctx, toResourceCustomTemplate(template, plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := checkGalleryVersion(template, plan); err != nil {
+		resp.Diagnostics.AddError("Error Updating Custom Template", err.Error())
+	}
+}
+
+// checkGalleryVersion returns an error if the API did not apply the planned
+// gallery version of the template.
+func checkGalleryVersion(template *tagmanager.CustomTemplate, plan resourceCustomTemplateModel) error {
+	if plan.GalleryReference == nil || template.GalleryReference == nil {
+		return nil
+	}
+
+	if version := template.GalleryReference.Version; version != plan.GalleryReference.Version.ValueString() {
+		return fmt.Errorf("the API kept gallery version %s instead of %s, replace the template to change its version, e.g. with terraform apply -replace", version, plan.GalleryReference.Version.ValueString())
+	}
+
+	return nil
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *customTemplateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state resourceCustomTemplateModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := clientInWorkspace(ctx, r.client, state.AccountId, state.ContainerId, state.WorkspaceId)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Deleting Custom Template", err.Error())
+		return
+	}
+
+	err = client.DeleteTemplate(state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error Deleting Custom Template", err.Error())
+		return
+	}
+}
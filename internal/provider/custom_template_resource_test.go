@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/option"
+	"google.golang.org/api/tagmanager/v2"
+)
+
+func testGalleryTemplatePlan() resourceCustomTemplateModel {
+	return resourceCustomTemplateModel{
+		AccountId:    types.StringValue("6105084028"),
+		ContainerId:  types.StringValue("119458552"),
+		WorkspaceId:  types.StringValue("12"),
+		Name:         types.StringValue("Consent Mode (Google tags)"),
+		TemplateData: types.StringValue("___INFO___\r\n\r\n{\r\n  \"type\": \"TAG\",\r\n  \"displayName\": \"Consent Mode (Google tags)\"\r\n}\r\n\r\n\r\n___SANDBOXED_JS_FOR_WEB_TEMPLATE___\r\n\r\ndata.gtmOnSuccess();\r\n"),
+		GalleryReference: &resourceGalleryReferenceModel{
+			Host:       types.StringUnknown(),
+			Owner:      types.StringValue("gtm-templates-simo-ahava"),
+			Repository: types.StringValue("consent-mode"),
+			Version:    types.StringValue("5d5b6a3fb1a86e4c4a2a9ebee6e3b2a6a5e1d7a4"),
+			Signature:  types.StringUnknown(),
+			IsModified: types.BoolUnknown(),
+		},
+		Id:   types.StringUnknown(),
+		Type: types.StringUnknown(),
+	}
+}
+
+func TestToResourceCustomTemplateKeepsPlannedTemplateData(t *testing.T) {
+	var template tagmanager.CustomTemplate
+	loadApiResponse(t, "custom_template_gallery.json", &template)
+
+	plan := testGalleryTemplatePlan()
+	state := toResourceCustomTemplate(&template, plan)
+
+	assert.Equal(t, plan.TemplateData, state.TemplateData)
+	assert.Equal(t, types.StringValue("21"), state.Id)
+	assert.Equal(t, types.StringValue("cvt_119458552_21"), state.Type)
+	assert.Equal(t, types.StringValue("github.com"), state.GalleryReference.Host)
+	assert.Equal(t, types.StringValue("0b8c9e0d6a7f"), state.GalleryReference.Signature)
+	assert.Equal(t, types.BoolValue(false), state.GalleryReference.IsModified)
+
+	// A refresh keeps the state as it is.
+	assert.Equal(t, state, toResourceCustomTemplate(&template, state))
+}
+
+func TestToResourceCustomTemplateDetectsChangedTemplateData(t *testing.T) {
+	var template tagmanager.CustomTemplate
+	loadApiResponse(t, "custom_template_gallery.json", &template)
+
+	prior := testGalleryTemplatePlan()
+	template.TemplateData += "___NOTES___\n\nChanged in the UI.\n"
+	state := toResourceCustomTemplate(&template, prior)
+
+	assert.Equal(t, types.StringValue(template.TemplateData), state.TemplateData)
+}
+
+func TestCheckGalleryVersion(t *testing.T) {
+	var template tagmanager.CustomTemplate
+	loadApiResponse(t, "custom_template_gallery.json", &template)
+
+	plan := testGalleryTemplatePlan()
+	assert.NoError(t, checkGalleryVersion(&template, plan))
+
+	plan.GalleryReference.Version = types.StringValue("9f1e2d3c4b5a69788796a5b4c3d2e1f0a9b8c7d6")
+	assert.ErrorContains(t, checkGalleryVersion(&template, plan), "kept gallery version 5d5b6a3fb1a86e4c4a2a9ebee6e3b2a6a5e1d7a4")
+}
+
+func TestUpdateCustomTemplateSavesStateOfKeptGalleryVersion(t *testing.T) {
+	response, err := os.ReadFile(filepath.Join("testdata", "custom_template_gallery.json"))
+	require.NoError(t, err)
+
+	// The API answers the update with the template at its former version.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(response)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	service, err := tagmanager.NewService(ctx, option.WithEndpoint(server.URL), option.WithHTTPClient(server.Client()))
+	require.NoError(t, err)
+	client := testClient()
+	client.Client.Service = service
+
+	var template tagmanager.CustomTemplate
+	loadApiResponse(t, "custom_template_gallery.json", &template)
+	prior := toResourceCustomTemplate(&template, testGalleryTemplatePlan())
+	plan := prior
+	plan.GalleryReference = &resourceGalleryReferenceModel{}
+	*plan.GalleryReference = *prior.GalleryReference
+	plan.GalleryReference.Version = types.StringValue("9f1e2d3c4b5a69788796a5b4c3d2e1f0a9b8c7d6")
+
+	var schemaResp resource.SchemaResponse
+	r := &customTemplateResource{client: client}
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	require.False(t, state.Set(ctx, prior).HasError())
+	planned := tfsdk.State{Schema: schemaResp.Schema, Raw: state.Raw}
+	require.False(t, planned.Set(ctx, plan).HasError())
+
+	req := resource.UpdateRequest{
+		Plan:  tfsdk.Plan{Schema: schemaResp.Schema, Raw: planned.Raw},
+		State: state,
+	}
+	resp := resource.UpdateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: planned.Raw}}
+	r.Update(ctx, req, &resp)
+
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "kept gallery version")
+
+	var saved resourceCustomTemplateModel
+	require.False(t, resp.State.Get(ctx, &saved).HasError())
+	assert.Equal(t, types.StringValue(template.GalleryReference.Version), saved.GalleryReference.Version)
+}
@@ -0,0 +1,183 @@
+package provider
+
+import (
+	"context"
+	"terraform-provider-google-tag-manager/internal/api"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/api/tagmanager/v2"
+)
+
+var (
+	_ resource.ResourceWithConfigure = &destinationResource{}
+)
+
+func NewDestinationResource() resource.Resource {
+	return &destinationResource{}
+}
+
+type destinationResource struct {
+	client *api.ClientInWorkspace
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *destinationResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(*api.ClientInWorkspace)
+}
+
+// Metadata returns the resource type name.
+func (r *destinationResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_destination"
+}
+
+var destinationResourceSchemaAttributes = map[string]schema.Attribute{
+	"account_id":   accountIdSchema,
+	"container_id": containerIdSchema,
+	"destination_id": schema.StringAttribute{
+		Description:   "The ID of the destination, e.g. a GA4 measurement ID (G-XXXX) or an Ads account (AW-XXXX).",
+		Required:      true,
+		PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+	},
+	"allow_user_permission_feature_update": schema.BoolAttribute{
+		Description: "Allow linking to turn on the user permissions feature of the container.",
+		Optional:    true,
+	},
+	"id": schema.StringAttribute{
+		Description:   "The ID of the destination link.",
+		Computed:      true,
+		PlanModifiers: idPlanModifiers,
+	},
+	"name": schema.StringAttribute{
+		Description: "The name of the destination.",
+		Computed:    true,
+	},
+}
+
+// Schema defines the schema for the resource.
+func (r *destinationResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Links a destination to a container. The API cannot unlink a destination: destroying this resource only removes it from the state, and the destination stays linked until it is linked to another container.",
+		Attributes:  destinationResourceSchemaAttributes,
+	}
+}
+
+type resourceDestinationModel struct {
+	AccountId                        types.String `tfsdk:"account_id"`
+	ContainerId                      types.String `tfsdk:"container_id"`
+	DestinationId                    types.String `tfsdk:"destination_id"`
+	AllowUserPermissionFeatureUpdate types.Bool   `tfsdk:"allow_user_permission_feature_update"`
+	Id                               types.String `tfsdk:"id"`
+	Name                             types.String `tfsdk:"name"`
+}
+
+// overwriteDestinationResource copies the destination into the resource,
+// keeping the attributes that exist only in the configuration.
+func overwriteDestinationResource(destination *tagmanager.Destination, resource *resourceDestinationModel) {
+	resource.AccountId = types.StringValue(destination.AccountId)
+	resource.ContainerId = types.StringValue(destination.ContainerId)
+	resource.DestinationId = types.StringValue(destination.DestinationId)
+	resource.Id = types.StringValue(destination.DestinationLinkId)
+	resource.Name = types.StringValue(destination.Name)
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *destinationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan resourceDestinationModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.client.WithContext(ctx).InContainer(plan.AccountId.ValueString(), plan.ContainerId.ValueString())
+	destination, err := client.LinkDestination(plan.DestinationId.ValueString(), plan.AllowUserPermissionFeatureUpdate.ValueBool())
+	if err != nil {
+		resp.Diagnostics.AddError("Error Linking Destination", err.Error())
+		return
+	}
+
+	overwriteDestinationResource(destination, &plan)
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data. A destination
+// that was unlinked or linked to another container is removed from the state.
+func (r *destinationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state resourceDestinationModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.client.WithContext(ctx).InContainer(state.AccountId.ValueString(), state.ContainerId.ValueString())
+	destination, err := client.Destination(state.Id.ValueString())
+	if err == api.ErrNotExist {
+		resp.State.RemoveResource(ctx)
+		return
+	} else if err != nil {
+		resp.Diagnostics.AddError("Error Reading Destination", err.Error())
+		return
+	}
+
+	overwriteDestinationResource(destination, &state)
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update only stores the new configuration, since every attribute that
+// reaches the API forces a new link.
+func (r *destinationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state resourceDestinationModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Id = state.Id
+	plan.Name = state.Name
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete removes the Terraform state. The destination stays linked.
+func (r *destinationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state resourceDestinationModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.AddWarning(
+		"Destination Still Linked",
+		"The Tag Manager API cannot unlink destinations. "+state.DestinationId.ValueString()+
+			" was removed from the Terraform state but stays linked to container "+state.ContainerId.ValueString()+
+			" until it is unlinked in the Tag Manager UI or linked to another container.",
+	)
+}
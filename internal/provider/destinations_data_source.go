@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"context"
+	"terraform-provider-google-tag-manager/internal/api"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSourceWithConfigure = &destinationsDataSource{}
+)
+
+func NewDestinationsDataSource() datasource.DataSource {
+	return &destinationsDataSource{}
+}
+
+type destinationsDataSource struct {
+	client *api.ClientInWorkspace
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *destinationsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(*api.ClientInWorkspace)
+}
+
+// Metadata returns the data source type name.
+func (d *destinationsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_destinations"
+}
+
+// Schema defines the schema for the data source.
+func (d *destinationsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the destinations linked to a container.",
+		Attributes: map[string]schema.Attribute{
+			"account_id": schema.StringAttribute{
+				Description: "GTM Account ID. Defaults to the account_id of the provider.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"container_id": schema.StringAttribute{
+				Description: "GTM Container ID. Defaults to the container_id of the provider.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"destination": schema.ListNestedAttribute{
+				Description: "The destinations linked to the container.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"destination_id": schema.StringAttribute{
+							Description: "The ID of the destination.",
+							Computed:    true,
+						},
+						"id": schema.StringAttribute{
+							Description: "The ID of the destination link.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "The name of the destination.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+type dataSourceDestinationModel struct {
+	DestinationId types.String `tfsdk:"destination_id"`
+	Id            types.String `tfsdk:"id"`
+	Name          types.String `tfsdk:"name"`
+}
+
+type dataSourceDestinationsModel struct {
+	AccountId   types.String                 `tfsdk:"account_id"`
+	ContainerId types.String                 `tfsdk:"container_id"`
+	Destination []dataSourceDestinationModel `tfsdk:"destination"`
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *destinationsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config dataSourceDestinationsModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := d.client.WithContext(ctx).InContainer(config.AccountId.ValueString(), config.ContainerId.ValueString())
+	destinations, err := client.ListDestinations()
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Destinations", err.Error())
+		return
+	}
+
+	state := dataSourceDestinationsModel{
+		AccountId:   types.StringValue(client.Options.AccountId),
+		ContainerId: types.StringValue(client.Options.ContainerId),
+		Destination: make([]dataSourceDestinationModel, len(destinations)),
+	}
+	for i, destination := range destinations {
+		state.Destination[i] = dataSourceDestinationModel{
+			DestinationId: types.StringValue(destination.DestinationId),
+			Id:            types.StringValue(destination.DestinationLinkId),
+			Name:          types.StringValue(destination.Name),
+		}
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
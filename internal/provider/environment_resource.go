@@ -0,0 +1,334 @@
+package provider
+
+import (
+	"context"
+	"terraform-provider-google-tag-manager/internal/api"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/api/tagmanager/v2"
+)
+
+var (
+	_ resource.ResourceWithConfigure = &environmentResource{}
+)
+
+func NewEnvironmentResource() resource.Resource {
+	return &environmentResource{}
+}
+
+type environmentResource struct {
+	client *api.ClientInWorkspace
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *environmentResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(*api.ClientInWorkspace)
+}
+
+// Metadata returns the resource type name.
+func (r *environmentResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_environment"
+}
+
+var environmentResourceSchemaAttributes = map[string]schema.Attribute{
+	"account_id":   accountIdSchema,
+	"container_id": containerIdSchema,
+	"name": schema.StringAttribute{
+		Description: "The name of the environment.",
+		Required:    true,
+	},
+	"description": schema.StringAttribute{
+		Description: "The description of the environment.",
+		Optional:    true,
+	},
+	"url": schema.StringAttribute{
+		Description: "The default preview page URL of the environment.",
+		Optional:    true,
+	},
+	"enable_debug": schema.BoolAttribute{
+		Description: "Whether debugging is enabled in the environment. Defaults to false.",
+		Optional:    true,
+		Computed:    true,
+		Default:     booldefault.StaticBool(false),
+	},
+	"container_version_id": schema.StringAttribute{
+		Description: "The ID of the container version linked to the environment.",
+		Optional:    true,
+	},
+	"reauthorize": schema.StringAttribute{
+		Description: "An arbitrary value. Changing it re-generates the authorization code of the environment.",
+		Optional:    true,
+	},
+	"id": schema.StringAttribute{
+		Description:   "The ID of the environment.",
+		Computed:      true,
+		PlanModifiers: idPlanModifiers,
+	},
+	"authorization_code": schema.StringAttribute{
+		Description: "The authorization code of the environment.",
+		Computed:    true,
+		Sensitive:   true,
+	},
+	"authorization_timestamp": schema.StringAttribute{
+		Description: "The time the authorization code was last generated.",
+		Computed:    true,
+	},
+	"container_public_id": schema.StringAttribute{
+		Description:   "The public ID of the container, e.g. GTM-XXXX, which preview_snippet loads.",
+		Computed:      true,
+		PlanModifiers: idPlanModifiers,
+	},
+	"preview_query": schema.StringAttribute{
+		Description: "The query parameters that load the container in the environment.",
+		Computed:    true,
+		Sensitive:   true,
+	},
+	"preview_snippet": schema.StringAttribute{
+		Description: "The container snippet for the head of a page that loads the container in the environment.",
+		Computed:    true,
+		Sensitive:   true,
+	},
+}
+
+// Schema defines the schema for the resource.
+func (r *environmentResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{Attributes: environmentResourceSchemaAttributes}
+}
+
+type resourceEnvironmentModel struct {
+	AccountId              types.String `tfsdk:"account_id"`
+	ContainerId            types.String `tfsdk:"container_id"`
+	Name                   types.String `tfsdk:"name"`
+	Description            types.String `tfsdk:"description"`
+	Url                    types.String `tfsdk:"url"`
+	EnableDebug            types.Bool   `tfsdk:"enable_debug"`
+	ContainerVersionId     types.String `tfsdk:"container_version_id"`
+	Reauthorize            types.String `tfsdk:"reauthorize"`
+	Id                     types.String `tfsdk:"id"`
+	AuthorizationCode      types.String `tfsdk:"authorization_code"`
+	AuthorizationTimestamp types.String `tfsdk:"authorization_timestamp"`
+	ContainerPublicId      types.String `tfsdk:"container_public_id"`
+	PreviewQuery           types.String `tfsdk:"preview_query"`
+	PreviewSnippet         types.String `tfsdk:"preview_snippet"`
+}
+
+// overwriteEnvironmentResource copies the environment into the resource,
+// keeping the attributes that exist only in the configuration. The preview
+// snippet is null as long as the container public ID is not known.
+func overwriteEnvironmentResource(env *tagmanager.Environment, resource *resourceEnvironmentModel) {
+	resource.AccountId = types.StringValue(env.AccountId)
+	resource.ContainerId = types.StringValue(env.ContainerId)
+	resource.Name = types.StringValue(env.Name)
+	resource.Description = nullableStringValue(env.Description)
+	resource.Url = nullableStringValue(env.Url)
+	resource.EnableDebug = types.BoolValue(env.EnableDebug)
+	resource.ContainerVersionId = nullableStringValue(env.ContainerVersionId)
+	resource.Id = types.StringValue(env.EnvironmentId)
+	resource.AuthorizationCode = types.StringValue(env.AuthorizationCode)
+	resource.AuthorizationTimestamp = types.StringValue(env.AuthorizationTimestamp)
+	resource.PreviewQuery = types.StringValue(environmentPreviewQuery(env))
+	if resource.ContainerPublicId.IsNull() || resource.ContainerPublicId.IsUnknown() {
+		resource.ContainerPublicId = types.StringNull()
+		resource.PreviewSnippet = types.StringNull()
+	} else {
+		resource.PreviewSnippet = types.StringValue(environmentPreviewSnippet(env, resource.ContainerPublicId.ValueString()))
+	}
+}
+
+// setContainerPublicId reads the public ID of the container of the
+// environment, unless the resource has it already. The public ID of a
+// container never changes, so it is read once, when the environment is
+// created.
+func setContainerPublicId(client *api.Client, env *tagmanager.Environment, resource *resourceEnvironmentModel) error {
+	if !resource.ContainerPublicId.IsNull() && !resource.ContainerPublicId.IsUnknown() {
+		return nil
+	}
+
+	container, err := client.Container(env.ContainerId)
+	if err != nil {
+		return err
+	}
+
+	resource.ContainerPublicId = types.StringValue(container.PublicId)
+	overwriteEnvironmentResource(env, resource)
+	return nil
+}
+
+func toApiEnvironment(resource resourceEnvironmentModel) *tagmanager.Environment {
+	return &tagmanager.Environment{
+		Name:               resource.Name.ValueString(),
+		Description:        resource.Description.ValueString(),
+		Url:                resource.Url.ValueString(),
+		EnableDebug:        resource.EnableDebug.ValueBool(),
+		ContainerVersionId: resource.ContainerVersionId.ValueString(),
+	}
+}
+
+func environmentPreviewQuery(env *tagmanager.Environment) string {
+	return "gtm_auth=" + env.AuthorizationCode + "&gtm_preview=env-" + env.EnvironmentId + "&gtm_cookies_win=x"
+}
+
+func environmentPreviewSnippet(env *tagmanager.Environment, containerPublicId string) string {
+	return `<!-- Google Tag Manager -->
+<script>(function(w,d,s,l,i){w[l]=w[l]||[];w[l].push({'gtm.start':
+new Date().getTime(),event:'gtm.js'});var f=d.getElementsByTagName(s)[0],
+j=d.createElement(s),dl=l!='dataLayer'?'&l='+l:'';j.async=true;j.src=
+'https://www.googletagmanager.com/gtm.js?id='+i+dl+ '&` + environmentPreviewQuery(env) + `';f.parentNode.insertBefore(j,f);
+})(window,document,'script','dataLayer','` + containerPublicId + `');</script>
+<!-- End Google Tag Manager -->`
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *environmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan resourceEnvironmentModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.client.WithContext(ctx).InContainer(plan.AccountId.ValueString(), plan.ContainerId.ValueString())
+	env, err := client.CreateEnvironment(toApiEnvironment(plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Creating Environment", err.Error())
+		return
+	}
+
+	// Save the environment before reading its container, so that it stays
+	// tracked if that fails.
+	overwriteEnvironmentResource(env, &plan)
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err = setContainerPublicId(client, env, &plan)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Container", err.Error())
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *environmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state resourceEnvironmentModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.client.WithContext(ctx).InContainer(state.AccountId.ValueString(), state.ContainerId.ValueString())
+	env, err := client.Environment(state.Id.ValueString())
+	if err == api.ErrNotExist {
+		resp.State.RemoveResource(ctx)
+		return
+	} else if err != nil {
+		resp.Diagnostics.AddError("Error Reading Environment", err.Error())
+		return
+	}
+
+	// Only a creation that failed to read the container leaves its public ID
+	// unknown.
+	overwriteEnvironmentResource(env, &state)
+	err = setContainerPublicId(client, env, &state)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Container", err.Error())
+		return
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *environmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state resourceEnvironmentModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.client.WithContext(ctx).InContainer(state.AccountId.ValueString(), state.ContainerId.ValueString())
+	env, err := client.UpdateEnvironment(state.Id.ValueString(), toApiEnvironment(plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Updating Environment", err.Error())
+		return
+	}
+
+	plan.ContainerPublicId = state.ContainerPublicId
+	overwriteEnvironmentResource(env, &plan)
+
+	if !plan.Reauthorize.Equal(state.Reauthorize) {
+		// Save the update with the prior reauthorize value, so that a failed
+		// reauthorization is retried by the next apply.
+		updated := plan
+		updated.Reauthorize = state.Reauthorize
+		diags = resp.State.Set(ctx, updated)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		env, err = client.ReauthorizeEnvironment(state.Id.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Error Reauthorizing Environment", err.Error())
+			return
+		}
+
+		overwriteEnvironmentResource(env, &plan)
+	}
+
+	err = setContainerPublicId(client, env, &plan)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Container", err.Error())
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *environmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state resourceEnvironmentModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.client.WithContext(ctx).InContainer(state.AccountId.ValueString(), state.ContainerId.ValueString())
+	err := client.DeleteEnvironment(state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error Deleting Environment", err.Error())
+		return
+	}
+}
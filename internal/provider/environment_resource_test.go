@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/option"
+	"google.golang.org/api/tagmanager/v2"
+)
+
+const testEnvironmentResponse = `{
+	"accountId": "6105084028",
+	"containerId": "119458552",
+	"environmentId": "5",
+	"type": "user",
+	"name": "staging",
+	"authorizationCode": "x1y2z3",
+	"authorizationTimestamp": "2023-06-01T12:00:00.000Z"
+}`
+
+// testEnvironmentServer answers environment calls with the environment and
+// container calls with the container, or with an error if container is empty.
+func testEnvironmentServer(t *testing.T, container string) *environmentResource {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.Contains(r.URL.Path, "/environments"):
+			_, _ = w.Write([]byte(testEnvironmentResponse))
+		case container != "":
+			_, _ = w.Write([]byte(container))
+		default:
+			http.Error(w, `{"error": {"code": 503, "message": "unavailable"}}`, http.StatusServiceUnavailable)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	service, err := tagmanager.NewService(context.Background(), option.WithEndpoint(server.URL), option.WithHTTPClient(server.Client()))
+	require.NoError(t, err)
+	client := testClient()
+	client.Client.Service = service
+
+	return &environmentResource{client: client}
+}
+
+func TestCreateEnvironmentSavesStateOfFailedContainerRead(t *testing.T) {
+	ctx := context.Background()
+	r := testEnvironmentServer(t, "")
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	empty := tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)
+
+	planned := tfsdk.State{Schema: schemaResp.Schema, Raw: empty}
+	require.False(t, planned.Set(ctx, resourceEnvironmentModel{
+		AccountId:              types.StringValue("6105084028"),
+		ContainerId:            types.StringValue("119458552"),
+		Name:                   types.StringValue("staging"),
+		Description:            types.StringNull(),
+		Url:                    types.StringNull(),
+		EnableDebug:            types.BoolValue(false),
+		ContainerVersionId:     types.StringNull(),
+		Reauthorize:            types.StringNull(),
+		Id:                     types.StringUnknown(),
+		AuthorizationCode:      types.StringUnknown(),
+		AuthorizationTimestamp: types.StringUnknown(),
+		ContainerPublicId:      types.StringUnknown(),
+		PreviewQuery:           types.StringUnknown(),
+		PreviewSnippet:         types.StringUnknown(),
+	}).HasError())
+
+	req := resource.CreateRequest{Plan: tfsdk.Plan{Schema: schemaResp.Schema, Raw: planned.Raw}}
+	resp := resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema, Raw: empty}}
+	r.Create(ctx, req, &resp)
+
+	require.True(t, resp.Diagnostics.HasError())
+	assert.Equal(t, "Error Reading Container", resp.Diagnostics.Errors()[0].Summary())
+
+	var saved resourceEnvironmentModel
+	require.False(t, resp.State.Get(ctx, &saved).HasError())
+	assert.Equal(t, types.StringValue("5"), saved.Id)
+	assert.True(t, saved.ContainerPublicId.IsNull())
+	assert.True(t, saved.PreviewSnippet.IsNull())
+
+	// The next refresh reads the container that the creation missed.
+	r = testEnvironmentServer(t, `{"accountId": "6105084028", "containerId": "119458552", "publicId": "GTM-TEST"}`)
+	readResp := resource.ReadResponse{State: resp.State}
+	r.Read(ctx, resource.ReadRequest{State: resp.State}, &readResp)
+	require.False(t, readResp.Diagnostics.HasError(), readResp.Diagnostics)
+
+	var refreshed resourceEnvironmentModel
+	require.False(t, readResp.State.Get(ctx, &refreshed).HasError())
+	assert.Equal(t, types.StringValue("GTM-TEST"), refreshed.ContainerPublicId)
+	assert.Contains(t, refreshed.PreviewSnippet.ValueString(), "'GTM-TEST'")
+	assert.Contains(t, refreshed.PreviewSnippet.ValueString(), "gtm_auth=x1y2z3&gtm_preview=env-5")
+}
+
+func TestReadEnvironmentKeepsContainerPublicId(t *testing.T) {
+	ctx := context.Background()
+	// The container cannot be read, so a refresh must not try to.
+	r := testEnvironmentServer(t, "")
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	prior := resourceEnvironmentModel{
+		AccountId:         types.StringValue("6105084028"),
+		ContainerId:       types.StringValue("119458552"),
+		Id:                types.StringValue("5"),
+		ContainerPublicId: types.StringValue("GTM-TEST"),
+	}
+	require.False(t, state.Set(ctx, prior).HasError())
+
+	resp := resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, &resp)
+	require.False(t, resp.Diagnostics.HasError(), resp.Diagnostics)
+
+	var refreshed resourceEnvironmentModel
+	require.False(t, resp.State.Get(ctx, &refreshed).HasError())
+	assert.Contains(t, refreshed.PreviewSnippet.ValueString(), "'GTM-TEST'")
+}
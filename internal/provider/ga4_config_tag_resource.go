@@ -0,0 +1,285 @@
+package provider
+
+import (
+	"context"
+	"terraform-provider-google-tag-manager/internal/api"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/api/tagmanager/v2"
+)
+
+var (
+	_ resource.ResourceWithConfigure      = &ga4ConfigTagResource{}
+	_ resource.ResourceWithValidateConfig = &ga4ConfigTagResource{}
+	_ resource.ResourceWithModifyPlan     = &ga4ConfigTagResource{}
+)
+
+func NewGa4ConfigTagResource() resource.Resource {
+	return &ga4ConfigTagResource{}
+}
+
+type ga4ConfigTagResource struct {
+	client *api.ClientInWorkspace
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *ga4ConfigTagResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(*api.ClientInWorkspace)
+}
+
+// Metadata returns the resource type name.
+func (r *ga4ConfigTagResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_ga4_config_tag"
+}
+
+var ga4ConfigTagResourceSchemaAttributes = map[string]schema.Attribute{
+	"account_id":   accountIdSchema,
+	"container_id": containerIdSchema,
+	"workspace_id": workspaceIdSchema,
+	"name": schema.StringAttribute{
+		Description: "The name of the tag.",
+		Required:    true},
+	"id": schema.StringAttribute{
+		Description:   "The ID of the tag.",
+		Computed:      true,
+		PlanModifiers: idPlanModifiers},
+	"notes": schema.StringAttribute{
+		Description: "The notes associated with the tag.",
+		Optional:    true},
+	"measurement_id": schema.StringAttribute{
+		Description: "The GA4 measurement ID, e.g. G-XXXXXXXXXX.",
+		Required:    true},
+	"send_page_view": schema.BoolAttribute{
+		Description: "Whether to send a page view event when the tag fires.",
+		Optional:    true},
+	"fields_to_set": schema.MapAttribute{
+		Description: "The configuration fields to set, by field name.",
+		Optional:    true,
+		ElementType: types.StringType,
+	},
+	"user_properties": schema.MapAttribute{
+		Description: "The user properties, by property name.",
+		Optional:    true,
+		ElementType: types.StringType,
+	},
+	"firing_trigger_id": schema.ListAttribute{
+		Description: "The ID of the firing triggers associated with the tag.",
+		Optional:    true,
+		ElementType: types.StringType,
+	},
+}
+
+// Schema defines the schema for the resource. It stays at version 0 and has no
+// state upgrader: the typed attributes replace the parameter sets, so the
+// parameter changes of parameterSchemaVersion never applied to it.
+func (r *ga4ConfigTagResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "A GA4 configuration tag (type gaawc).",
+		Attributes:  ga4ConfigTagResourceSchemaAttributes,
+	}
+}
+
+// ValidateConfig checks the variable references in the attributes that become
+// template parameters.
+func (r *ga4ConfigTagResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(validateConfigTemplates(ctx, req.Config, path.Root("measurement_id"), path.Root("fields_to_set"), path.Root("user_properties"))...)
+}
+
+// ModifyPlan checks the variable references of the tag against the
+// workspace.
+func (r *ga4ConfigTagResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resp.Diagnostics.Append(checkPlanVariableReferences(ctx, r.client, req)...)
+}
+
+type resourceGa4ConfigTagModel struct {
+	AccountId       types.String            `tfsdk:"account_id"`
+	ContainerId     types.String            `tfsdk:"container_id"`
+	WorkspaceId     types.String            `tfsdk:"workspace_id"`
+	Name            types.String            `tfsdk:"name"`
+	Id              types.String            `tfsdk:"id"`
+	Notes           types.String            `tfsdk:"notes"`
+	MeasurementId   types.String            `tfsdk:"measurement_id"`
+	SendPageView    types.Bool              `tfsdk:"send_page_view"`
+	FieldsToSet     map[string]types.String `tfsdk:"fields_to_set"`
+	UserProperties  map[string]types.String `tfsdk:"user_properties"`
+	FiringTriggerId []types.String          `tfsdk:"firing_trigger_id"`
+}
+
+// ga4ConfigTagToResourceTag compiles the GA4 configuration tag into a generic
+// tag.
+func ga4ConfigTagToResourceTag(resource resourceGa4ConfigTagModel) resourceTagModel {
+	parameter := []ResourceParameterModel{
+		templateParameter("measurementId", resource.MeasurementId),
+	}
+
+	if !resource.SendPageView.IsNull() {
+		parameter = append(parameter, booleanParameter("sendPageView", resource.SendPageView.ValueBool()))
+	}
+
+	if resource.FieldsToSet != nil {
+		parameter = append(parameter, tableParameter("fieldsToSet", "name", "value", resource.FieldsToSet))
+	}
+
+	if resource.UserProperties != nil {
+		parameter = append(parameter, tableParameter("userProperties", "name", "value", resource.UserProperties))
+	}
+
+	return resourceTagModel{
+		Name:            resource.Name,
+		Type:            types.StringValue("gaawc"),
+		Id:              resource.Id,
+		Notes:           resource.Notes,
+		Parameter:       parameter,
+		ParameterJson:   types.StringNull(),
+		Parameters:      types.StringNull(),
+		FiringTriggerId: resource.FiringTriggerId,
+	}
+}
+
+// toResourceGa4ConfigTag converts a tag of the API. As toResourceTag does, it
+// keeps the empty values of prior and ignores the parameters that the API
+// added with their defaults.
+func toResourceGa4ConfigTag(tag *tagmanager.Tag, prior resourceGa4ConfigTagModel) resourceGa4ConfigTagModel {
+	parameter := withoutServerDefaults(tag.Parameter, serverDefaultTagParameters[tag.Type], toApiTag(ga4ConfigTagToResourceTag(prior)).Parameter)
+
+	sendPageView := types.BoolNull()
+	if p := findParameter(parameter, "sendPageView"); p != nil {
+		sendPageView = types.BoolValue(p.Value == "true")
+	}
+
+	return resourceGa4ConfigTagModel{
+		AccountId:       types.StringValue(tag.AccountId),
+		ContainerId:     types.StringValue(tag.ContainerId),
+		WorkspaceId:     types.StringValue(tag.WorkspaceId),
+		Name:            types.StringValue(tag.Name),
+		Id:              types.StringValue(tag.TagId),
+		Notes:           priorStringValue(tag.Notes, prior.Notes),
+		MeasurementId:   priorStringValue(parameterValue(parameter, "measurementId").ValueString(), prior.MeasurementId),
+		SendPageView:    sendPageView,
+		FieldsToSet:     priorParameterTable(parameter, "fieldsToSet", "name", "value", prior.FieldsToSet),
+		UserProperties:  priorParameterTable(parameter, "userProperties", "name", "value", prior.UserProperties),
+		FiringTriggerId: priorStringArray(tag.FiringTriggerId, prior.FiringTriggerId),
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *ga4ConfigTagResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan resourceGa4ConfigTagModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := clientInWorkspace(ctx, r.client, plan.AccountId, plan.ContainerId, plan.WorkspaceId)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Creating GA4 Configuration Tag", err.Error())
+		return
+	}
+
+	tag, err := client.CreateTag(toApiTag(ga4ConfigTagToResourceTag(plan)))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Creating GA4 Configuration Tag", err.Error())
+		return
+	}
+
+	diags = resp.State.Set(ctx, toResourceGa4ConfigTag(tag, plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *ga4ConfigTagResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state resourceGa4ConfigTagModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := clientInWorkspace(ctx, r.client, state.AccountId, state.ContainerId, state.WorkspaceId)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading GA4 Configuration Tag", err.Error())
+		return
+	}
+
+	tag, err := client.Tag(state.Id.ValueString())
+	if err == api.ErrNotExist {
+		resp.State.RemoveResource(ctx)
+		return
+	} else if err != nil {
+		resp.Diagnostics.AddError("Error Reading GA4 Configuration Tag", err.Error())
+		return
+	}
+
+	diags = resp.State.Set(ctx, toResourceGa4ConfigTag(tag, state))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *ga4ConfigTagResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state resourceGa4ConfigTagModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := clientInWorkspace(ctx, r.client, state.AccountId, state.ContainerId, state.WorkspaceId)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Updating GA4 Configuration Tag", err.Error())
+		return
+	}
+
+	tag, err := client.UpdateTag(state.Id.ValueString(), toApiTag(ga4ConfigTagToResourceTag(plan)))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Updating GA4 Configuration Tag", err.Error())
+		return
+	}
+
+	diags = resp.State.Set(ctx, toResourceGa4ConfigTag(tag, plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *ga4ConfigTagResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state resourceGa4ConfigTagModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := clientInWorkspace(ctx, r.client, state.AccountId, state.ContainerId, state.WorkspaceId)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Deleting GA4 Configuration Tag", err.Error())
+		return
+	}
+
+	err = client.DeleteTag(state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error Deleting GA4 Configuration Tag", err.Error())
+		return
+	}
+}
@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/tagmanager/v2"
+)
+
+func testGa4ConfigTagPlan() resourceGa4ConfigTagModel {
+	return resourceGa4ConfigTagModel{
+		AccountId:     types.StringValue("6105084028"),
+		ContainerId:   types.StringValue("119458552"),
+		WorkspaceId:   types.StringValue("12"),
+		Name:          types.StringValue("test-ga4-config"),
+		Id:            types.StringUnknown(),
+		Notes:         types.StringNull(),
+		MeasurementId: types.StringValue("G-XXXXXXXX"),
+		SendPageView:  types.BoolNull(),
+		FieldsToSet:   map[string]types.String{},
+		UserProperties: map[string]types.String{
+			"plan": types.StringValue("{{Plan}}"),
+		},
+		FiringTriggerId: []types.String{types.StringValue("2147479573")},
+	}
+}
+
+func TestToResourceGa4ConfigTagIgnoresServerDefaults(t *testing.T) {
+	var tag tagmanager.Tag
+	loadApiResponse(t, "tag_gaawc.json", &tag)
+
+	plan := testGa4ConfigTagPlan()
+	state := toResourceGa4ConfigTag(&tag, plan)
+
+	plan.Id = types.StringValue("9")
+	assert.Equal(t, plan, state)
+
+	// A refresh keeps the state as it is.
+	assert.Equal(t, state, toResourceGa4ConfigTag(&tag, state))
+}
+
+func TestToResourceGa4ConfigTagKeepsConfiguredDefaults(t *testing.T) {
+	var tag tagmanager.Tag
+	loadApiResponse(t, "tag_gaawc.json", &tag)
+
+	plan := testGa4ConfigTagPlan()
+	plan.SendPageView = types.BoolValue(true)
+	state := toResourceGa4ConfigTag(&tag, plan)
+
+	assert.Equal(t, types.BoolValue(true), state.SendPageView)
+}
+
+func TestToResourceGa4ConfigTagDetectsChanges(t *testing.T) {
+	var tag tagmanager.Tag
+	loadApiResponse(t, "tag_gaawc.json", &tag)
+	findParameter(tag.Parameter, "sendPageView").Value = "false"
+
+	state := toResourceGa4ConfigTag(&tag, testGa4ConfigTagPlan())
+
+	assert.Equal(t, types.BoolValue(false), state.SendPageView)
+}
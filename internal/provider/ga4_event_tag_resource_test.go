@@ -0,0 +1,66 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/tagmanager/v2"
+)
+
+func testGa4EventTagPlan() resourceGa4EventTagModel {
+	return resourceGa4EventTagModel{
+		AccountId:     types.StringValue("6105084028"),
+		ContainerId:   types.StringValue("119458552"),
+		WorkspaceId:   types.StringValue("12"),
+		Name:          types.StringValue("test-purchase"),
+		Id:            types.StringUnknown(),
+		Notes:         types.StringValue(""),
+		MeasurementId: types.StringValue("G-XXXXXXXX"),
+		EventName:     types.StringValue("purchase"),
+		EventParameters: map[string]types.String{
+			"currency": types.StringValue("EUR"),
+		},
+		UserProperties:  map[string]types.String{},
+		FiringTriggerId: []types.String{},
+	}
+}
+
+func TestGa4EventTagToResourceTagSetsMeasurementIdOverride(t *testing.T) {
+	var tag tagmanager.Tag
+	loadApiResponse(t, "tag_gaawe.json", &tag)
+
+	parameter := toApiTag(ga4EventTagToResourceTag(testGa4EventTagPlan())).Parameter
+	require.NotNil(t, findParameter(parameter, "measurementIdOverride"))
+	assert.Equal(t, findParameter(tag.Parameter, "measurementIdOverride").Value, findParameter(parameter, "measurementIdOverride").Value)
+	assert.Nil(t, findParameter(parameter, "measurementId"))
+}
+
+func TestToResourceGa4EventTagKeepsEmptyValues(t *testing.T) {
+	var tag tagmanager.Tag
+	loadApiResponse(t, "tag_gaawe.json", &tag)
+
+	plan := testGa4EventTagPlan()
+	state := toResourceGa4EventTag(&tag, plan)
+
+	plan.Id = types.StringValue("8")
+	assert.Equal(t, plan, state)
+
+	// A refresh keeps the state as it is.
+	assert.Equal(t, state, toResourceGa4EventTag(&tag, state))
+}
+
+func TestToResourceGa4EventTagWithoutPriorValue(t *testing.T) {
+	var tag tagmanager.Tag
+	loadApiResponse(t, "tag_gaawe.json", &tag)
+
+	state := toResourceGa4EventTag(&tag, resourceGa4EventTagModel{})
+
+	assert.True(t, state.Notes.IsNull())
+	assert.Equal(t, types.StringValue("G-XXXXXXXX"), state.MeasurementId)
+	assert.Equal(t, types.StringValue("purchase"), state.EventName)
+	assert.Nil(t, state.UserProperties)
+	assert.Nil(t, state.FiringTriggerId)
+	assert.Equal(t, map[string]types.String{"currency": types.StringValue("EUR")}, state.EventParameters)
+}
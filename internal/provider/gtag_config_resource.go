@@ -0,0 +1,253 @@
+package provider
+
+import (
+	"context"
+	"terraform-provider-google-tag-manager/internal/api"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/api/tagmanager/v2"
+)
+
+var (
+	_ resource.ResourceWithConfigure      = &gtagConfigResource{}
+	_ resource.ResourceWithValidateConfig = &gtagConfigResource{}
+	_ resource.ResourceWithModifyPlan     = &gtagConfigResource{}
+	_ resource.ResourceWithUpgradeState   = &gtagConfigResource{}
+)
+
+func NewGtagConfigResource() resource.Resource {
+	return &gtagConfigResource{}
+}
+
+type gtagConfigResource struct {
+	client *api.ClientInWorkspace
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *gtagConfigResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(*api.ClientInWorkspace)
+}
+
+// Metadata returns the resource type name.
+func (r *gtagConfigResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_gtag_config"
+}
+
+var gtagConfigResourceSchemaAttributes = map[string]schema.Attribute{
+	"account_id":   accountIdSchema,
+	"container_id": containerIdSchema,
+	"workspace_id": workspaceIdSchema,
+	"type": schema.StringAttribute{
+		Description:   "The type of the Google tag configuration. Changing it recreates the Google tag configuration.",
+		Required:      true,
+		PlanModifiers: immutablePlanModifiers,
+	},
+	"id": schema.StringAttribute{
+		Description:   "The ID of the Google tag configuration.",
+		Computed:      true,
+		PlanModifiers: idPlanModifiers,
+	},
+	"parameter":      parameterSchema,
+	"parameter_json": parameterJsonSchema,
+	"parameters":     parametersSchema,
+}
+
+// Schema defines the schema for the resource.
+func (r *gtagConfigResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version:    parameterSchemaVersion,
+		Attributes: gtagConfigResourceSchemaAttributes,
+	}
+}
+
+// UpgradeState upgrades the state from prior schema versions.
+func (r *gtagConfigResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: parameterStateUpgrader(gtagConfigResourceSchemaAttributes, func() interface{} { return &resourceGtagConfigModel{} }),
+	}
+}
+
+// ValidateConfig checks the parameters of the Google tag configuration.
+func (r *gtagConfigResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(validateConfigParameters(ctx, req.Config)...)
+}
+
+// ModifyPlan checks the variable references of the Google tag configuration against the
+// workspace.
+func (r *gtagConfigResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resp.Diagnostics.Append(checkPlanVariableReferences(ctx, r.client, req)...)
+}
+
+type resourceGtagConfigModel struct {
+	AccountId     types.String             `tfsdk:"account_id"`
+	ContainerId   types.String             `tfsdk:"container_id"`
+	WorkspaceId   types.String             `tfsdk:"workspace_id"`
+	Type          types.String             `tfsdk:"type"`
+	Id            types.String             `tfsdk:"id"`
+	Parameter     []ResourceParameterModel `tfsdk:"parameter"`
+	ParameterJson types.String             `tfsdk:"parameter_json"`
+	Parameters    types.String             `tfsdk:"parameters"`
+}
+
+// Equal compares the two models and returns true if they are equal.
+func (m resourceGtagConfigModel) Equal(o resourceGtagConfigModel) bool {
+	if (!m.AccountId.IsUnknown() && !m.AccountId.Equal(o.AccountId)) ||
+		(!m.ContainerId.IsUnknown() && !m.ContainerId.Equal(o.ContainerId)) ||
+		(!m.WorkspaceId.IsUnknown() && !m.WorkspaceId.Equal(o.WorkspaceId)) ||
+		!m.Type.Equal(o.Type) ||
+		(!m.Id.IsUnknown() && !m.Id.Equal(o.Id)) ||
+		!m.ParameterJson.Equal(o.ParameterJson) ||
+		!m.Parameters.Equal(o.Parameters) {
+		return false
+	}
+
+	if !parameterSetsEqual(m.Parameter, o.Parameter) {
+		return false
+	}
+
+	return true
+}
+
+func toResourceGtagConfig(gtagConfig *tagmanager.GtagConfig, prior resourceGtagConfigModel) resourceGtagConfigModel {
+	parameter, parameterJson, parameters := toResourceParameters(gtagConfig.Parameter, nil, prior.Parameter, prior.ParameterJson, prior.Parameters)
+
+	return resourceGtagConfigModel{
+		AccountId:     types.StringValue(gtagConfig.AccountId),
+		ContainerId:   types.StringValue(gtagConfig.ContainerId),
+		WorkspaceId:   types.StringValue(gtagConfig.WorkspaceId),
+		Type:          types.StringValue(gtagConfig.Type),
+		Id:            types.StringValue(gtagConfig.GtagConfigId),
+		Parameter:     parameter,
+		ParameterJson: parameterJson,
+		Parameters:    parameters,
+	}
+}
+
+func toApiGtagConfig(resource resourceGtagConfigModel) *tagmanager.GtagConfig {
+	return &tagmanager.GtagConfig{
+		Type:         resource.Type.ValueString(),
+		GtagConfigId: resource.Id.ValueString(),
+		Parameter:    toApiParameters(resource.Parameter, resource.ParameterJson, resource.Parameters),
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *gtagConfigResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan resourceGtagConfigModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := clientInWorkspace(ctx, r.client, plan.AccountId, plan.ContainerId, plan.WorkspaceId)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Creating Google Tag Config", err.Error())
+		return
+	}
+
+	gtagConfig, err := client.CreateGtagConfig(toApiGtagConfig(plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Creating Google Tag Config", err.Error())
+		return
+	}
+
+	diags = resp.State.Set(ctx, toResourceGtagConfig(gtagConfig, plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *gtagConfigResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state resourceGtagConfigModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := clientInWorkspace(ctx, r.client, state.AccountId, state.ContainerId, state.WorkspaceId)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Google Tag Config", err.Error())
+		return
+	}
+
+	gtagConfig, err := client.GtagConfig(state.Id.ValueString())
+	if err == api.ErrNotExist {
+		resp.State.RemoveResource(ctx)
+		return
+	} else if err != nil {
+		resp.Diagnostics.AddError("Error Reading Google Tag Config", err.Error())
+		return
+	}
+
+	diags = resp.State.Set(ctx, toResourceGtagConfig(gtagConfig, state))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *gtagConfigResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state resourceGtagConfigModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := clientInWorkspace(ctx, r.client, state.AccountId, state.ContainerId, state.WorkspaceId)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Updating Google Tag Config", err.Error())
+		return
+	}
+
+	gtagConfig, err := client.UpdateGtagConfig(state.Id.ValueString(), toApiGtagConfig(plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Updating Google Tag Config", err.Error())
+		return
+	}
+
+	diags = resp.State.Set(ctx, toResourceGtagConfig(gtagConfig, plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *gtagConfigResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state resourceGtagConfigModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := clientInWorkspace(ctx, r.client, state.AccountId, state.ContainerId, state.WorkspaceId)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Deleting Google Tag Config", err.Error())
+		return
+	}
+
+	err = client.DeleteGtagConfig(state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error Deleting Google Tag Config", err.Error())
+		return
+	}
+}
@@ -0,0 +1,403 @@
+package provider
+
+import (
+	"context"
+	"terraform-provider-google-tag-manager/internal/api"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/api/tagmanager/v2"
+)
+
+var (
+	_ resource.ResourceWithConfigure  = &lookupTableVariableResource{}
+	_ resource.ResourceWithModifyPlan = &lookupTableVariableResource{}
+)
+
+func NewLookupTableVariableResource() resource.Resource {
+	return &lookupTableVariableResource{}
+}
+
+type lookupTableVariableResource struct {
+	client *api.ClientInWorkspace
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *lookupTableVariableResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(*api.ClientInWorkspace)
+}
+
+// Metadata returns the resource type name.
+func (r *lookupTableVariableResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_lookup_table_variable"
+}
+
+var lookupTableVariableResourceSchemaAttributes = map[string]schema.Attribute{
+	"account_id":   accountIdSchema,
+	"container_id": containerIdSchema,
+	"workspace_id": workspaceIdSchema,
+	"name": schema.StringAttribute{
+		Description: "The name of the variable.",
+		Required:    true,
+	},
+	"id": schema.StringAttribute{
+		Description:   "The ID of the variable.",
+		Computed:      true,
+		PlanModifiers: idPlanModifiers,
+	},
+	"notes": schema.StringAttribute{
+		Description: "The notes of the variable.",
+		Optional:    true,
+	},
+	"input": schema.StringAttribute{
+		Description: "The variable reference to look up, e.g. the Page Path variable in double curly braces.",
+		Required:    true,
+	},
+	"rows": schema.ListNestedAttribute{
+		Description: "The rows of the table. The output of the first row whose pattern matches the input is used, so catch-all patterns of regex tables go last.",
+		Required:    true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"pattern": schema.StringAttribute{
+					Description: "The input value of the row, or its regular expression in a regex table.",
+					Required:    true,
+				},
+				"output": schema.StringAttribute{
+					Description: "The output of the variable when the row matches.",
+					Required:    true,
+				},
+			},
+		},
+	},
+	"default_value": schema.StringAttribute{
+		Description: "The output when no row matches. Without it the variable is undefined when no row matches.",
+		Optional:    true,
+	},
+	"regex": schema.SingleNestedAttribute{
+		Description: "Makes the variable a regex table (type remm) whose rows are regular expressions. Without it the variable is a lookup table (type smm). Adding or removing it recreates the variable, since the type of a variable cannot be changed.",
+		Optional:    true,
+		PlanModifiers: []planmodifier.Object{
+			objectplanmodifier.RequiresReplaceIf(regexTableChanged, "Adding or removing regex recreates the variable.", "Adding or removing regex recreates the variable."),
+		},
+		Attributes: map[string]schema.Attribute{
+			"ignore_case": schema.BoolAttribute{
+				Description: "Whether the patterns ignore case. Defaults to true.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"full_match": schema.BoolAttribute{
+				Description: "Whether the patterns must match the whole input. Defaults to true.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"capture_groups": schema.BoolAttribute{
+				Description: "Whether outputs can refer to capture groups, e.g. $1. Defaults to true.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+		},
+	},
+}
+
+// regexTableChanged returns whether the variable changes between a lookup
+// table and a regex table.
+func regexTableChanged(_ context.Context, req planmodifier.ObjectRequest, resp *objectplanmodifier.RequiresReplaceIfFuncResponse) {
+	resp.RequiresReplace = req.StateValue.IsNull() != req.PlanValue.IsNull()
+}
+
+// Schema defines the schema for the resource.
+func (r *lookupTableVariableResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "A lookup table (type smm) or regex table (type remm) variable.",
+		Attributes:  lookupTableVariableResourceSchemaAttributes,
+	}
+}
+
+// ModifyPlan checks the variable references of the variable against the
+// workspace.
+func (r *lookupTableVariableResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resp.Diagnostics.Append(checkPlanVariableReferences(ctx, r.client, req)...)
+}
+
+type resourceLookupTableRegexModel struct {
+	IgnoreCase    types.Bool `tfsdk:"ignore_case"`
+	FullMatch     types.Bool `tfsdk:"full_match"`
+	CaptureGroups types.Bool `tfsdk:"capture_groups"`
+}
+
+type resourceLookupTableRowModel struct {
+	Pattern types.String `tfsdk:"pattern"`
+	Output  types.String `tfsdk:"output"`
+}
+
+type resourceLookupTableVariableModel struct {
+	AccountId    types.String                   `tfsdk:"account_id"`
+	ContainerId  types.String                   `tfsdk:"container_id"`
+	WorkspaceId  types.String                   `tfsdk:"workspace_id"`
+	Name         types.String                   `tfsdk:"name"`
+	Id           types.String                   `tfsdk:"id"`
+	Notes        types.String                   `tfsdk:"notes"`
+	Input        types.String                   `tfsdk:"input"`
+	Rows         []resourceLookupTableRowModel  `tfsdk:"rows"`
+	DefaultValue types.String                   `tfsdk:"default_value"`
+	Regex        *resourceLookupTableRegexModel `tfsdk:"regex"`
+}
+
+// lookupTableRowsParameter encodes the rows as the map parameter of a lookup
+// table, i.e. a list of maps with a key column and a value column, in the
+// order of the rows.
+func lookupTableRowsParameter(rows []resourceLookupTableRowModel) ResourceParameterModel {
+	list := make([]ResourceParameterModel, len(rows))
+	for i, row := range rows {
+		list[i] = ResourceParameterModel{
+			Type: types.StringValue("map"),
+			Map: []ResourceParameterModel{
+				templateParameter("key", row.Pattern),
+				templateParameter("value", row.Output),
+			},
+		}
+	}
+
+	return ResourceParameterModel{
+		Key:  types.StringValue("map"),
+		Type: types.StringValue("list"),
+		List: list,
+	}
+}
+
+// lookupTableVariableToResourceVariable compiles the lookup table into a
+// generic variable.
+func lookupTableVariableToResourceVariable(resource resourceLookupTableVariableModel) resourceVariableModel {
+	variableType := "smm"
+	parameter := []ResourceParameterModel{
+		templateParameter("input", resource.Input),
+		lookupTableRowsParameter(resource.Rows),
+	}
+
+	if !resource.DefaultValue.IsNull() {
+		parameter = append(parameter,
+			booleanParameter("setDefaultValue", true),
+			templateParameter("defaultValue", resource.DefaultValue),
+		)
+	}
+
+	if resource.Regex != nil {
+		variableType = "remm"
+		parameter = append(parameter,
+			booleanParameter("ignoreCase", resource.Regex.IgnoreCase.ValueBool()),
+			booleanParameter("fullMatch", resource.Regex.FullMatch.ValueBool()),
+			booleanParameter("replaceAttribute", resource.Regex.CaptureGroups.ValueBool()),
+		)
+	}
+
+	return resourceVariableModel{
+		Name:          resource.Name,
+		Type:          types.StringValue(variableType),
+		Id:            resource.Id,
+		Notes:         resource.Notes,
+		Parameter:     parameter,
+		ParameterJson: types.StringNull(),
+		Parameters:    types.StringNull(),
+	}
+}
+
+// findResourceParameter returns the parameter with the given key, or nil.
+func findResourceParameter(parameter []ResourceParameterModel, key string) *ResourceParameterModel {
+	for i := range parameter {
+		if parameter[i].Key.ValueString() == key {
+			return &parameter[i]
+		}
+	}
+
+	return nil
+}
+
+// resourceParameterBool returns whether the parameter with the given key is
+// set to true.
+func resourceParameterBool(parameter []ResourceParameterModel, key string) bool {
+	p := findResourceParameter(parameter, key)
+	return p != nil && p.Value.ValueString() == "true"
+}
+
+// toResourceLookupTableVariable converts a variable of the API. Empty notes
+// of prior are kept.
+func toResourceLookupTableVariable(variable *tagmanager.Variable, prior resourceLookupTableVariableModel) resourceLookupTableVariableModel {
+	parameter := toResourceParameter(variable.Parameter)
+
+	input := types.StringNull()
+	if p := findResourceParameter(parameter, "input"); p != nil {
+		input = p.Value
+	}
+
+	rows := []resourceLookupTableRowModel{}
+	if p := findResourceParameter(parameter, "map"); p != nil {
+		for _, row := range p.List {
+			key := findResourceParameter(row.Map, "key")
+			if key == nil {
+				continue
+			}
+
+			output := types.StringValue("")
+			if value := findResourceParameter(row.Map, "value"); value != nil && !value.Value.IsNull() {
+				output = value.Value
+			}
+			rows = append(rows, resourceLookupTableRowModel{Pattern: types.StringValue(key.Value.ValueString()), Output: output})
+		}
+	}
+
+	defaultValue := types.StringNull()
+	if resourceParameterBool(parameter, "setDefaultValue") {
+		defaultValue = types.StringValue("")
+		if p := findResourceParameter(parameter, "defaultValue"); p != nil && !p.Value.IsNull() {
+			defaultValue = p.Value
+		}
+	}
+
+	var regex *resourceLookupTableRegexModel
+	if variable.Type == "remm" {
+		regex = &resourceLookupTableRegexModel{
+			IgnoreCase:    types.BoolValue(resourceParameterBool(parameter, "ignoreCase")),
+			FullMatch:     types.BoolValue(resourceParameterBool(parameter, "fullMatch")),
+			CaptureGroups: types.BoolValue(resourceParameterBool(parameter, "replaceAttribute")),
+		}
+	}
+
+	return resourceLookupTableVariableModel{
+		AccountId:    types.StringValue(variable.AccountId),
+		ContainerId:  types.StringValue(variable.ContainerId),
+		WorkspaceId:  types.StringValue(variable.WorkspaceId),
+		Name:         types.StringValue(variable.Name),
+		Id:           types.StringValue(variable.VariableId),
+		Notes:        priorStringValue(variable.Notes, prior.Notes),
+		Input:        input,
+		Rows:         rows,
+		DefaultValue: defaultValue,
+		Regex:        regex,
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *lookupTableVariableResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan resourceLookupTableVariableModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := clientInWorkspace(ctx, r.client, plan.AccountId, plan.ContainerId, plan.WorkspaceId)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Creating Lookup Table Variable", err.Error())
+		return
+	}
+
+	variable, err := client.CreateVariable(toApiVariable(lookupTableVariableToResourceVariable(plan)))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Creating Lookup Table Variable", err.Error())
+		return
+	}
+
+	diags = resp.State.Set(ctx, toResourceLookupTableVariable(variable, plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *lookupTableVariableResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state resourceLookupTableVariableModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := clientInWorkspace(ctx, r.client, state.AccountId, state.ContainerId, state.WorkspaceId)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Lookup Table Variable", err.Error())
+		return
+	}
+
+	variable, err := client.Variable(state.Id.ValueString())
+	if err == api.ErrNotExist {
+		resp.State.RemoveResource(ctx)
+		return
+	} else if err != nil {
+		resp.Diagnostics.AddError("Error Reading Lookup Table Variable", err.Error())
+		return
+	}
+
+	diags = resp.State.Set(ctx, toResourceLookupTableVariable(variable, state))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *lookupTableVariableResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state resourceLookupTableVariableModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := clientInWorkspace(ctx, r.client, state.AccountId, state.ContainerId, state.WorkspaceId)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Updating Lookup Table Variable", err.Error())
+		return
+	}
+
+	variable, err := client.UpdateVariable(state.Id.ValueString(), toApiVariable(lookupTableVariableToResourceVariable(plan)))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Updating Lookup Table Variable", err.Error())
+		return
+	}
+
+	diags = resp.State.Set(ctx, toResourceLookupTableVariable(variable, plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *lookupTableVariableResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state resourceLookupTableVariableModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := clientInWorkspace(ctx, r.client, state.AccountId, state.ContainerId, state.WorkspaceId)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Deleting Lookup Table Variable", err.Error())
+		return
+	}
+
+	err = client.DeleteVariable(state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error Deleting Lookup Table Variable", err.Error())
+		return
+	}
+}
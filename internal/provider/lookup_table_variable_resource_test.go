@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/tagmanager/v2"
+)
+
+func testLookupTableRow(pattern, output string) resourceLookupTableRowModel {
+	return resourceLookupTableRowModel{Pattern: types.StringValue(pattern), Output: types.StringValue(output)}
+}
+
+func testLookupTablePlan() resourceLookupTableVariableModel {
+	return resourceLookupTableVariableModel{
+		AccountId:   types.StringValue("6105084028"),
+		ContainerId: types.StringValue("119458552"),
+		WorkspaceId: types.StringValue("12"),
+		Name:        types.StringValue("test-measurement-id"),
+		Id:          types.StringUnknown(),
+		Notes:       types.StringNull(),
+		Input:       types.StringValue("{{Page Hostname}}"),
+		Rows: []resourceLookupTableRowModel{
+			testLookupTableRow("www.example.com", "G-A2ABC2ABCD"),
+			testLookupTableRow("staging.example.com", ""),
+		},
+		DefaultValue: types.StringValue("G-C4CDE4CDEF"),
+	}
+}
+
+func testRegexTablePlan() resourceLookupTableVariableModel {
+	return resourceLookupTableVariableModel{
+		AccountId:   types.StringValue("6105084028"),
+		ContainerId: types.StringValue("119458552"),
+		WorkspaceId: types.StringValue("12"),
+		Name:        types.StringValue("test-page-section"),
+		Id:          types.StringUnknown(),
+		Notes:       types.StringNull(),
+		Input:       types.StringValue("{{Page Path}}"),
+		Rows: []resourceLookupTableRowModel{
+			testLookupTableRow("^/checkout(/.*)?$", "checkout"),
+			testLookupTableRow("^/(blog|news)/", "$1"),
+			testLookupTableRow(".*", "other"),
+		},
+		DefaultValue: types.StringNull(),
+		Regex: &resourceLookupTableRegexModel{
+			IgnoreCase:    types.BoolValue(true),
+			FullMatch:     types.BoolValue(false),
+			CaptureGroups: types.BoolValue(true),
+		},
+	}
+}
+
+// assertLookupTableRoundTrip checks that the plan compiles into the
+// parameters of the API response and that the response converts back into
+// the plan.
+func assertLookupTableRoundTrip(t *testing.T, plan resourceLookupTableVariableModel, response string) {
+	var variable tagmanager.Variable
+	loadApiResponse(t, response, &variable)
+
+	sent := toApiVariable(lookupTableVariableToResourceVariable(plan))
+	assert.Equal(t, variable.Type, sent.Type)
+	received := withoutServerDefaults(variable.Parameter, serverDefaultVariableParameters[variable.Type], sent.Parameter)
+	assert.Equal(t, encodeParameterJson(canonicalParameters(sent.Parameter)), encodeParameterJson(canonicalParameters(received)))
+
+	state := toResourceLookupTableVariable(&variable, plan)
+	plan.Id = types.StringValue(variable.VariableId)
+	assert.Equal(t, plan, state)
+}
+
+func TestLookupTableVariableRoundTrip(t *testing.T) {
+	assertLookupTableRoundTrip(t, testLookupTablePlan(), "variable_smm.json")
+}
+
+func TestRegexTableVariableRoundTrip(t *testing.T) {
+	assertLookupTableRoundTrip(t, testRegexTablePlan(), "variable_remm.json")
+}
+
+func TestRegexTableVariableKeepsRowOrder(t *testing.T) {
+	plan := testRegexTablePlan()
+	sent := toApiVariable(lookupTableVariableToResourceVariable(plan))
+
+	rows := findParameter(sent.Parameter, "map")
+	require.NotNil(t, rows)
+	require.Len(t, rows.List, 3)
+	assert.Equal(t, ".*", findParameter(rows.List[2].Map, "key").Value, "the catch-all row must stay last")
+
+	// Reordered rows in the API are detected.
+	var variable tagmanager.Variable
+	loadApiResponse(t, "variable_remm.json", &variable)
+	table := findParameter(variable.Parameter, "map")
+	table.List[0], table.List[2] = table.List[2], table.List[0]
+
+	state := toResourceLookupTableVariable(&variable, plan)
+	assert.Equal(t, types.StringValue(".*"), state.Rows[0].Pattern)
+}
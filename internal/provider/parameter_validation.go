@@ -0,0 +1,321 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/api/tagmanager/v2"
+)
+
+// parameterTypes are the parameter types of the API.
+var parameterTypes = []string{"template", "integer", "boolean", "list", "map", "triggerReference", "tagReference"}
+
+// parameterProblem is a rule violation at a parameter attribute. Since
+// top-level parameters and map entries are sets, path indexes the parameter
+// lists like lists and only serves to render the location with
+// parameterLocation.
+type parameterProblem struct {
+	path    path.Path
+	summary string
+	detail  string
+}
+
+// validateParameterList checks the parameters of a list. Map children and
+// top-level parameters must have unique keys, list items must not have keys.
+func validateParameterList(parameter []ResourceParameterModel, at path.Path, keyed bool) []parameterProblem {
+	var problems []parameterProblem
+	keys := map[string]bool{}
+
+	for i, p := range parameter {
+		item := at.AtListIndex(i)
+
+		switch {
+		case p.Key.IsUnknown():
+		case keyed && p.Key.ValueString() == "":
+			problems = append(problems, parameterProblem{item.AtName("key"), "Missing Parameter Key", "Top-level parameters and map entries must have a key."})
+		case keyed && keys[p.Key.ValueString()]:
+			problems = append(problems, parameterProblem{item.AtName("key"), "Duplicate Parameter Key", fmt.Sprintf("The key %q is used more than once.", p.Key.ValueString())})
+		case !keyed && !p.Key.IsNull():
+			problems = append(problems, parameterProblem{item.AtName("key"), "Unexpected Parameter Key", "List items must not have a key."})
+		}
+		keys[p.Key.ValueString()] = true
+
+		problems = append(problems, validateParameter(p, item)...)
+	}
+
+	return problems
+}
+
+// validateParameter checks that the value, list and map of a parameter fit its
+// type, and that template values only contain well-formed variable
+// references.
+func validateParameter(p ResourceParameterModel, at path.Path) []parameterProblem {
+	if p.Type.IsUnknown() {
+		return nil
+	}
+
+	var problems []parameterProblem
+	t := p.Type.ValueString()
+
+	known := false
+	for _, pt := range parameterTypes {
+		known = known || t == pt
+	}
+	if !known {
+		return []parameterProblem{{at.AtName("type"), "Invalid Parameter Type", fmt.Sprintf("%q is not one of %s.", t, strings.Join(parameterTypes, ", "))}}
+	}
+
+	if t == "list" || t == "map" {
+		if !p.Value.IsNull() && !p.Value.IsUnknown() {
+			problems = append(problems, parameterProblem{at.AtName("value"), "Unexpected Parameter Value", fmt.Sprintf("A %s parameter has no value.", t)})
+		}
+	} else if p.List != nil || p.Map != nil {
+		problems = append(problems, parameterProblem{at, "Unexpected Nested Parameters", fmt.Sprintf("A %s parameter has neither list nor map.", t)})
+	}
+
+	if t == "list" && p.Map != nil {
+		problems = append(problems, parameterProblem{at.AtName("map"), "Unexpected Nested Parameters", "A list parameter has no map, put its items in list."})
+	}
+
+	if t == "map" && p.List != nil {
+		problems = append(problems, parameterProblem{at.AtName("list"), "Unexpected Nested Parameters", "A map parameter has no list, put its entries in map."})
+	}
+
+	problems = append(problems, validateParameterList(p.List, at.AtName("list"), false)...)
+	problems = append(problems, validateParameterList(p.Map, at.AtName("map"), true)...)
+
+	if p.Value.IsUnknown() {
+		return problems
+	}
+
+	value := p.Value.ValueString()
+	switch t {
+	case "template":
+		if err := checkVariableReferences(value); err != nil {
+			problems = append(problems, parameterProblem{at.AtName("value"), "Invalid Variable Reference", err.Error()})
+		}
+	case "integer":
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil && !isVariableReference(value) {
+			problems = append(problems, parameterProblem{at.AtName("value"), "Invalid Integer Parameter", fmt.Sprintf("%q is neither an integer nor a variable reference.", value)})
+		}
+	case "boolean":
+		if value != "true" && value != "false" {
+			problems = append(problems, parameterProblem{at.AtName("value"), "Invalid Boolean Parameter", fmt.Sprintf("%q is neither true nor false.", value)})
+		}
+	case "triggerReference", "tagReference":
+		if value == "" {
+			problems = append(problems, parameterProblem{at.AtName("value"), "Missing Reference", fmt.Sprintf("A %s parameter needs the ID or name it refers to as value.", t)})
+		}
+	}
+
+	return problems
+}
+
+// isVariableReference returns whether s is exactly one variable reference.
+func isVariableReference(s string) bool {
+	return strings.HasPrefix(s, "{{") && strings.Index(s, "}}") == len(s)-2 && checkVariableReferences(s) == nil
+}
+
+// checkVariableReferences checks that every {{ in s starts a reference to a
+// variable name that is closed by }}. A }} on its own is left alone, since
+// scripts often contain it.
+func checkVariableReferences(s string) error {
+	for {
+		start := strings.Index(s, "{{")
+		if start < 0 {
+			return nil
+		}
+
+		s = s[start+2:]
+		end := strings.Index(s, "}}")
+		if end < 0 {
+			return fmt.Errorf("The variable reference {{%s is not closed by }}.", firstLine(s))
+		}
+
+		name := s[:end]
+		if strings.TrimSpace(name) == "" || strings.ContainsAny(name, "{}\n") {
+			return fmt.Errorf("{{%s}} is not a valid variable reference.", firstLine(name))
+		}
+
+		s = s[end+2:]
+	}
+}
+
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+
+	return s
+}
+
+// validateConfigParameters checks the parameters of a resource, whichever of
+// parameter, parameter_json and parameters sets them. Problems in the JSON
+// attributes are reported on the attribute with the position inside it.
+func validateConfigParameters(ctx context.Context, config tfsdk.Config) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	parameter, ok := configParameterModels(ctx, config, path.Root("parameter"))
+	if ok {
+		diags.Append(parameterDiagnostics(parameter, path.Root("parameter"), path.Root("parameter"))...)
+	}
+
+	decoders := map[string]func(string) ([]*tagmanager.Parameter, error){
+		"parameters":     decodeParametersObject,
+		"parameter_json": decodeParameterJson,
+	}
+	for name, decode := range decoders {
+		var value types.String
+		if d := config.GetAttribute(ctx, path.Root(name), &value); d.HasError() || value.IsNull() || value.IsUnknown() {
+			continue
+		}
+
+		decoded, err := decode(value.ValueString())
+		if err != nil {
+			continue
+		}
+
+		diags.Append(parameterDiagnostics(toResourceParameter(decoded), path.Root(name), path.Root(name))...)
+	}
+
+	return diags
+}
+
+// parameterDiagnostics validates a keyed parameter list and reports the
+// problems on the attribute at, with their location inside it starting at
+// the attribute named root.
+func parameterDiagnostics(parameter []ResourceParameterModel, at path.Path, root path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for _, p := range validateParameterList(parameter, root, true) {
+		diags.AddAttributeError(at, p.summary, fmt.Sprintf("At %s: %s", parameterLocation(parameter, p.path), p.detail))
+	}
+
+	return diags
+}
+
+// parameterLocation renders a path into a parameter list, naming the
+// parameters that have keys by their key rather than their index.
+func parameterLocation(parameter []ResourceParameterModel, p path.Path) string {
+	var b strings.Builder
+	var current *ResourceParameterModel
+
+	for _, step := range p.Steps() {
+		switch step := step.(type) {
+		case path.PathStepAttributeName:
+			if b.Len() > 0 {
+				b.WriteString(".")
+			}
+			b.WriteString(string(step))
+
+			if current != nil && step == "list" {
+				parameter = current.List
+			} else if current != nil && step == "map" {
+				parameter = current.Map
+			}
+		case path.PathStepElementKeyInt:
+			i := int(step)
+			if i < len(parameter) && parameter[i].Key.ValueString() != "" {
+				current = &parameter[i]
+				fmt.Fprintf(&b, "[%q]", current.Key.ValueString())
+			} else {
+				if i < len(parameter) {
+					current = &parameter[i]
+				}
+				fmt.Fprintf(&b, "[%d]", i)
+			}
+		}
+	}
+
+	return b.String()
+}
+
+// validateConfigConditions checks the parameters of the conditions in the
+// condition lists at the given paths.
+func validateConfigConditions(ctx context.Context, config tfsdk.Config, paths ...path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for _, at := range paths {
+		var value attr.Value
+		if d := config.GetAttribute(ctx, at, &value); d.HasError() || value == nil || value.IsNull() || value.IsUnknown() {
+			continue
+		}
+
+		var condition []resourceConditionModel
+		if d := config.GetAttribute(ctx, at, &condition); d.HasError() {
+			continue
+		}
+
+		for i, c := range condition {
+			diags.Append(parameterDiagnostics(c.Parameter, at.AtListIndex(i).AtName("parameter"), path.Root("parameter"))...)
+		}
+	}
+
+	return diags
+}
+
+// validateConfigTemplates checks the variable references in the string
+// attributes and string maps at the given paths, which resources with typed
+// attributes compile into template parameters.
+func validateConfigTemplates(ctx context.Context, config tfsdk.Config, paths ...path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for _, at := range paths {
+		var value attr.Value
+		if d := config.GetAttribute(ctx, at, &value); d.HasError() || value == nil || value.IsNull() || value.IsUnknown() {
+			continue
+		}
+
+		templates := map[string]attr.Value{"": value}
+		if m, ok := value.(types.Map); ok {
+			templates = m.Elements()
+		}
+
+		keys := make([]string, 0, len(templates))
+		for key := range templates {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			s, ok := templates[key].(types.String)
+			if !ok || s.IsNull() || s.IsUnknown() {
+				continue
+			}
+
+			if err := checkVariableReferences(s.ValueString()); err != nil {
+				p := at
+				if _, ok := value.(types.Map); ok {
+					p = at.AtMapKey(key)
+				}
+				diags.AddAttributeError(p, "Invalid Variable Reference", err.Error())
+			}
+		}
+	}
+
+	return diags
+}
+
+// configParameterModels reads a parameter list from the configuration. It
+// returns false when the list is null or not known yet.
+func configParameterModels(ctx context.Context, config tfsdk.Config, at path.Path) ([]ResourceParameterModel, bool) {
+	var value attr.Value
+	if d := config.GetAttribute(ctx, at, &value); d.HasError() || value == nil || value.IsNull() || value.IsUnknown() {
+		return nil, false
+	}
+
+	var parameter []ResourceParameterModel
+	if d := config.GetAttribute(ctx, at, &parameter); d.HasError() {
+		return nil, false
+	}
+
+	return parameter, true
+}
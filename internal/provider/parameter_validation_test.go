@@ -0,0 +1,234 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateParameter(t *testing.T) {
+	entry := func(key, value string) ResourceParameterModel {
+		return ResourceParameterModel{Key: types.StringValue(key), Type: types.StringValue("template"), Value: types.StringValue(value)}
+	}
+	item := func(value string) ResourceParameterModel {
+		return ResourceParameterModel{Key: types.StringNull(), Type: types.StringValue("template"), Value: types.StringValue(value)}
+	}
+
+	tests := map[string]struct {
+		parameter ResourceParameterModel
+		summaries []string
+	}{
+		"template": {
+			parameter: ResourceParameterModel{Type: types.StringValue("template"), Value: types.StringValue("{{Page URL}}?id={{Click ID}}")},
+		},
+		"template with a }} of a script": {
+			parameter: ResourceParameterModel{Type: types.StringValue("template"), Value: types.StringValue("<script>if (a) { if (b) {} }}</script>")},
+		},
+		"template with an unclosed reference": {
+			parameter: ResourceParameterModel{Type: types.StringValue("template"), Value: types.StringValue("{{Page URL")},
+			summaries: []string{"Invalid Variable Reference"},
+		},
+		"template with an empty reference": {
+			parameter: ResourceParameterModel{Type: types.StringValue("template"), Value: types.StringValue("id={{ }}")},
+			summaries: []string{"Invalid Variable Reference"},
+		},
+		"template with a nested reference": {
+			parameter: ResourceParameterModel{Type: types.StringValue("template"), Value: types.StringValue("{{Page {{URL}}")},
+			summaries: []string{"Invalid Variable Reference"},
+		},
+		"template of unknown value": {
+			parameter: ResourceParameterModel{Type: types.StringValue("template"), Value: types.StringUnknown()},
+		},
+		"integer": {
+			parameter: ResourceParameterModel{Type: types.StringValue("integer"), Value: types.StringValue("-42")},
+		},
+		"integer reference": {
+			parameter: ResourceParameterModel{Type: types.StringValue("integer"), Value: types.StringValue("{{Timeout}}")},
+		},
+		"integer with text": {
+			parameter: ResourceParameterModel{Type: types.StringValue("integer"), Value: types.StringValue("{{Timeout}}ms")},
+			summaries: []string{"Invalid Integer Parameter"},
+		},
+		"boolean": {
+			parameter: ResourceParameterModel{Type: types.StringValue("boolean"), Value: types.StringValue("false")},
+		},
+		"boolean in capitals": {
+			parameter: ResourceParameterModel{Type: types.StringValue("boolean"), Value: types.StringValue("True")},
+			summaries: []string{"Invalid Boolean Parameter"},
+		},
+		"list": {
+			parameter: ResourceParameterModel{Type: types.StringValue("list"), List: []ResourceParameterModel{item("a"), item("{{b}}")}},
+		},
+		"list with a value": {
+			parameter: ResourceParameterModel{Type: types.StringValue("list"), Value: types.StringValue("a"), List: []ResourceParameterModel{item("a")}},
+			summaries: []string{"Unexpected Parameter Value"},
+		},
+		"list with a map": {
+			parameter: ResourceParameterModel{Type: types.StringValue("list"), Map: []ResourceParameterModel{entry("a", "b")}},
+			summaries: []string{"Unexpected Nested Parameters"},
+		},
+		"list item with a key": {
+			parameter: ResourceParameterModel{Type: types.StringValue("list"), List: []ResourceParameterModel{entry("a", "b")}},
+			summaries: []string{"Unexpected Parameter Key"},
+		},
+		"list item with a malformed reference": {
+			parameter: ResourceParameterModel{Type: types.StringValue("list"), List: []ResourceParameterModel{item("{{a")}},
+			summaries: []string{"Invalid Variable Reference"},
+		},
+		"map": {
+			parameter: ResourceParameterModel{Type: types.StringValue("map"), Map: []ResourceParameterModel{entry("name", "a"), entry("value", "{{b}}")}},
+		},
+		"map with a list": {
+			parameter: ResourceParameterModel{Type: types.StringValue("map"), List: []ResourceParameterModel{item("a")}},
+			summaries: []string{"Unexpected Nested Parameters"},
+		},
+		"map child without a key": {
+			parameter: ResourceParameterModel{Type: types.StringValue("map"), Map: []ResourceParameterModel{item("a")}},
+			summaries: []string{"Missing Parameter Key"},
+		},
+		"map child with a duplicate key": {
+			parameter: ResourceParameterModel{Type: types.StringValue("map"), Map: []ResourceParameterModel{entry("name", "a"), entry("name", "b")}},
+			summaries: []string{"Duplicate Parameter Key"},
+		},
+		"template with a map": {
+			parameter: ResourceParameterModel{Type: types.StringValue("template"), Value: types.StringValue("a"), Map: []ResourceParameterModel{entry("a", "b")}},
+			summaries: []string{"Unexpected Nested Parameters"},
+		},
+		"triggerReference": {
+			parameter: ResourceParameterModel{Type: types.StringValue("triggerReference"), Value: types.StringValue("12")},
+		},
+		"triggerReference without value": {
+			parameter: ResourceParameterModel{Type: types.StringValue("triggerReference"), Value: types.StringValue("")},
+			summaries: []string{"Missing Reference"},
+		},
+		"tagReference": {
+			parameter: ResourceParameterModel{Type: types.StringValue("tagReference"), Value: types.StringValue("Setup tag")},
+		},
+		"tagReference without value": {
+			parameter: ResourceParameterModel{Type: types.StringValue("tagReference"), Value: types.StringNull()},
+			summaries: []string{"Missing Reference"},
+		},
+		"unknown parameter type": {
+			parameter: ResourceParameterModel{Type: types.StringValue("string"), Value: types.StringValue("a")},
+			summaries: []string{"Invalid Parameter Type"},
+		},
+		"parameter type not known yet": {
+			parameter: ResourceParameterModel{Type: types.StringUnknown(), Value: types.StringValue("{{a")},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var summaries []string
+			for _, p := range validateParameter(test.parameter, path.Root("parameter").AtListIndex(0)) {
+				summaries = append(summaries, p.summary)
+			}
+
+			assert.Equal(t, test.summaries, summaries)
+		})
+	}
+}
+
+func TestParameterDiagnosticsLocation(t *testing.T) {
+	parameter := []ResourceParameterModel{{
+		Key:  types.StringValue("eventSettingsTable"),
+		Type: types.StringValue("list"),
+		List: []ResourceParameterModel{{
+			Type: types.StringValue("map"),
+			Map: []ResourceParameterModel{
+				{Key: types.StringValue("parameter"), Type: types.StringValue("template"), Value: types.StringValue("value")},
+				{Key: types.StringValue("parameterValue"), Type: types.StringValue("template"), Value: types.StringValue("{{Page URL")},
+			},
+		}},
+	}}
+
+	diags := parameterDiagnostics(parameter, path.Root("parameter_json"), path.Root("parameter_json"))
+	require.Len(t, diags, 1)
+	assert.Equal(t, `At parameter_json["eventSettingsTable"].list[0].map["parameterValue"].value: The variable reference {{Page URL is not closed by }}.`, diags[0].Detail())
+}
+
+// validateZoneConfig runs the configuration validation of the zone.
+func validateZoneConfig(t *testing.T, zone resourceZoneModel) diag.Diagnostics {
+	ctx := context.Background()
+	s := schema.Schema{Attributes: zoneResourceSchemaAttributes}
+	state := tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}
+	require.False(t, state.Set(ctx, &zone).HasError())
+
+	req := resource.ValidateConfigRequest{Config: tfsdk.Config{Schema: s, Raw: state.Raw}}
+	var resp resource.ValidateConfigResponse
+	(&zoneResource{}).ValidateConfig(ctx, req, &resp)
+
+	return resp.Diagnostics
+}
+
+func TestZoneValidateConfigChecksBoundaryConditions(t *testing.T) {
+	zone := resourceZoneModel{
+		AccountId:   types.StringValue("6105084028"),
+		ContainerId: types.StringValue("119458552"),
+		WorkspaceId: types.StringValue("12"),
+		Name:        types.StringValue("Checkout"),
+	}
+	assert.Empty(t, validateZoneConfig(t, zone))
+
+	condition := func(value string) resourceConditionModel {
+		return resourceConditionModel{
+			Type: types.StringValue("contains"),
+			Parameter: []ResourceParameterModel{
+				{Key: types.StringValue("arg0"), Type: types.StringValue("template"), Value: types.StringValue("{{Page URL}}")},
+				{Key: types.StringValue("arg1"), Type: types.StringValue("template"), Value: types.StringValue(value)},
+			},
+		}
+	}
+
+	zone.Boundary = &resourceZoneBoundaryModel{Condition: []resourceConditionModel{condition("/checkout")}}
+	assert.Empty(t, validateZoneConfig(t, zone))
+
+	zone.Boundary.Condition = append(zone.Boundary.Condition, condition("{{Checkout Path"))
+	diags := validateZoneConfig(t, zone)
+	require.Len(t, diags, 1)
+	assert.Equal(t, "Invalid Variable Reference", diags[0].Summary())
+	assert.Equal(t, path.Root("boundary").AtName("condition").AtListIndex(1).AtName("parameter"), diags[0].(diag.DiagnosticWithPath).Path())
+}
+
+func TestGa4EventTagValidateConfigChecksVariableReferences(t *testing.T) {
+	ctx := context.Background()
+	validate := func(tag resourceGa4EventTagModel) diag.Diagnostics {
+		s := schema.Schema{Attributes: ga4EventTagResourceSchemaAttributes}
+		state := tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)}
+		require.False(t, state.Set(ctx, &tag).HasError())
+
+		req := resource.ValidateConfigRequest{Config: tfsdk.Config{Schema: s, Raw: state.Raw}}
+		var resp resource.ValidateConfigResponse
+		(&ga4EventTagResource{}).ValidateConfig(ctx, req, &resp)
+
+		return resp.Diagnostics
+	}
+
+	tag := resourceGa4EventTagModel{
+		AccountId:       types.StringValue("6105084028"),
+		ContainerId:     types.StringValue("119458552"),
+		WorkspaceId:     types.StringValue("12"),
+		Name:            types.StringValue("test-purchase"),
+		MeasurementId:   types.StringValue("{{GA4 Measurement ID}}"),
+		EventName:       types.StringValue("purchase"),
+		EventParameters: map[string]types.String{"currency": types.StringValue("EUR")},
+	}
+	assert.Empty(t, validate(tag))
+
+	tag.EventName = types.StringValue("{{Event")
+	tag.EventParameters["value"] = types.StringValue("{{}}")
+	diags := validate(tag)
+	require.Len(t, diags, 2)
+	assert.Equal(t, path.Root("event_name"), diags[0].(diag.DiagnosticWithPath).Path())
+	assert.Equal(t, path.Root("event_parameters").AtMapKey("value"), diags[1].(diag.DiagnosticWithPath).Path())
+	assert.Equal(t, "Invalid Variable Reference", diags[1].Summary())
+}
@@ -0,0 +1,465 @@
+package provider
+
+import (
+	"context"
+	"terraform-provider-google-tag-manager/internal/api"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testPlan is the outcome of planning a change of a resource.
+type testPlan struct {
+	planned         map[string]tftypes.Value
+	requiresReplace []*tftypes.AttributePath
+}
+
+// planResourceChange plans the change of a resource from the prior state to
+// the configuration with an unconfigured provider. A nil prior plans the
+// creation of the resource. As Terraform does, the proposed new state takes
+// the prior values of computed attributes that are not configured.
+func planResourceChange(t *testing.T, typeName string, prior, config map[string]tftypes.Value) testPlan {
+	ctx := context.Background()
+
+	server, err := providerserver.NewProtocol6WithError(New())()
+	require.NoError(t, err)
+
+	schemas, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	require.NoError(t, err)
+	s, ok := schemas.ResourceSchemas[typeName]
+	require.True(t, ok, "no schema for %s", typeName)
+	objectType := s.ValueType().(tftypes.Object)
+
+	configValue := testObject(objectType, config)
+	priorValue := tftypes.NewValue(objectType, nil)
+	proposed := map[string]tftypes.Value{}
+	for name, value := range config {
+		proposed[name] = value
+	}
+	if prior != nil {
+		priorValue = testObject(objectType, prior)
+		for _, attribute := range s.Block.Attributes {
+			if _, ok := config[attribute.Name]; !ok && attribute.Computed {
+				proposed[attribute.Name] = prior[attribute.Name]
+			}
+		}
+	}
+
+	dynamicValue := func(v tftypes.Value) *tfprotov6.DynamicValue {
+		dv, err := tfprotov6.NewDynamicValue(objectType, v)
+		require.NoError(t, err)
+		return &dv
+	}
+
+	resp, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         typeName,
+		PriorState:       dynamicValue(priorValue),
+		ProposedNewState: dynamicValue(testObject(objectType, proposed)),
+		Config:           dynamicValue(configValue),
+	})
+	require.NoError(t, err)
+	for _, d := range resp.Diagnostics {
+		require.NotEqual(t, tfprotov6.DiagnosticSeverityError, d.Severity, "%s: %s", d.Summary, d.Detail)
+	}
+
+	planned, err := resp.PlannedState.Unmarshal(objectType)
+	require.NoError(t, err)
+
+	var attributes map[string]tftypes.Value
+	require.NoError(t, planned.As(&attributes))
+
+	return testPlan{planned: attributes, requiresReplace: resp.RequiresReplace}
+}
+
+// testObject returns an object of the given type with the given attributes
+// and all other attributes null.
+func testObject(objectType tftypes.Object, attributes map[string]tftypes.Value) tftypes.Value {
+	values := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		if value, ok := attributes[name]; ok && value.Type() != nil {
+			values[name] = value
+		} else {
+			values[name] = tftypes.NewValue(attributeType, nil)
+		}
+	}
+
+	return tftypes.NewValue(objectType, values)
+}
+
+func testString(s string) tftypes.Value {
+	return tftypes.NewValue(tftypes.String, s)
+}
+
+// replaces returns whether the plan replaces the resource because of the
+// named attribute.
+func (p testPlan) replaces(name string) bool {
+	for _, path := range p.requiresReplace {
+		if path.Equal(tftypes.NewAttributePath().WithAttributeName(name)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// testState returns the state of a resource in the test workspace.
+func testState(attributes map[string]tftypes.Value) map[string]tftypes.Value {
+	state := map[string]tftypes.Value{
+		"account_id":   testString("6105084028"),
+		"container_id": testString("119458552"),
+		"workspace_id": testString("12"),
+	}
+	for name, value := range attributes {
+		state[name] = value
+	}
+
+	return state
+}
+
+var testTagState = testState(map[string]tftypes.Value{
+	"id":   testString("7"),
+	"name": testString("test-html"),
+	"type": testString("html"),
+})
+
+func TestPlanTagCreate(t *testing.T) {
+	plan := planResourceChange(t, "gtm_tag", nil, map[string]tftypes.Value{
+		"name": testString("test-html"),
+		"type": testString("html"),
+	})
+
+	assert.False(t, plan.planned["id"].IsKnown())
+	assert.False(t, plan.planned["workspace_id"].IsKnown())
+}
+
+func TestPlanTagRename(t *testing.T) {
+	plan := planResourceChange(t, "gtm_tag", testTagState, map[string]tftypes.Value{
+		"name": testString("renamed"),
+		"type": testString("html"),
+	})
+
+	assert.True(t, plan.planned["id"].Equal(testString("7")), "id is %s", plan.planned["id"])
+	assert.True(t, plan.planned["workspace_id"].Equal(testString("12")))
+	assert.True(t, plan.planned["name"].Equal(testString("renamed")))
+	assert.Empty(t, plan.requiresReplace)
+
+	var apiJson string
+	require.NoError(t, plan.planned["api_json"].As(&apiJson))
+	assert.Contains(t, apiJson, `"name": "renamed"`)
+	assert.NotContains(t, apiJson, `"tagId"`)
+}
+
+func TestPlanTagTypeChange(t *testing.T) {
+	plan := planResourceChange(t, "gtm_tag", testTagState, map[string]tftypes.Value{
+		"name": testString("test-html"),
+		"type": testString("img"),
+	})
+
+	assert.True(t, plan.replaces("type"))
+}
+
+func TestPlanTagMove(t *testing.T) {
+	plan := planResourceChange(t, "gtm_tag", testTagState, map[string]tftypes.Value{
+		"workspace_id": testString("13"),
+		"name":         testString("test-html"),
+		"type":         testString("html"),
+	})
+
+	assert.True(t, plan.replaces("workspace_id"))
+}
+
+func TestPlanVariableNotesChange(t *testing.T) {
+	plan := planResourceChange(t, "gtm_variable", testState(map[string]tftypes.Value{
+		"id":   testString("9"),
+		"name": testString("test-data-layer"),
+		"type": testString("v"),
+	}), map[string]tftypes.Value{
+		"name":  testString("test-data-layer"),
+		"type":  testString("v"),
+		"notes": testString("The order value."),
+	})
+
+	assert.True(t, plan.planned["id"].Equal(testString("9")))
+	assert.Empty(t, plan.requiresReplace)
+}
+
+func TestPlanTriggerTypeChange(t *testing.T) {
+	plan := planResourceChange(t, "gtm_trigger", testState(map[string]tftypes.Value{
+		"id":   testString("3"),
+		"name": testString("test-trigger"),
+		"type": testString("pageview"),
+	}), map[string]tftypes.Value{
+		"name": testString("test-trigger"),
+		"type": testString("domReady"),
+	})
+
+	assert.True(t, plan.replaces("type"))
+}
+
+func TestPlanWorkspaceDescriptionChange(t *testing.T) {
+	plan := planResourceChange(t, "gtm_workspace", map[string]tftypes.Value{
+		"account_id":      testString("6105084028"),
+		"container_id":    testString("119458552"),
+		"id":              testString("12"),
+		"name":            testString("my-workspace"),
+		"fingerprint":     testString("1697703011352"),
+		"path":            testString("accounts/6105084028/containers/119458552/workspaces/12"),
+		"tag_manager_url": testString("https://tagmanager.google.com/#/container/accounts/6105084028/containers/119458552/workspaces/12?apiLink=workspace"),
+	}, map[string]tftypes.Value{
+		"name":        testString("my-workspace"),
+		"description": testString("Managed by Terraform"),
+	})
+
+	assert.True(t, plan.planned["id"].Equal(testString("12")))
+	assert.True(t, plan.planned["path"].IsKnown())
+	assert.True(t, plan.planned["tag_manager_url"].IsKnown())
+	assert.False(t, plan.planned["fingerprint"].IsKnown())
+	assert.Empty(t, plan.requiresReplace)
+}
+
+// resourceObjectType returns the type of the values of a resource.
+func resourceObjectType(t *testing.T, typeName string) tftypes.Object {
+	ctx := context.Background()
+	server, err := providerserver.NewProtocol6WithError(New())()
+	require.NoError(t, err)
+	schemas, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	require.NoError(t, err)
+
+	return schemas.ResourceSchemas[typeName].ValueType().(tftypes.Object)
+}
+
+func TestPlanLookupTableVariableRegexChange(t *testing.T) {
+	objectType := resourceObjectType(t, "gtm_lookup_table_variable")
+
+	rowType := objectType.AttributeTypes["rows"].(tftypes.List).ElementType.(tftypes.Object)
+	rows := tftypes.NewValue(objectType.AttributeTypes["rows"], []tftypes.Value{
+		testObject(rowType, map[string]tftypes.Value{"pattern": testString("/"), "output": testString("home")}),
+	})
+	state := testState(map[string]tftypes.Value{
+		"id":    testString("11"),
+		"name":  testString("test-page-name"),
+		"input": testString("{{Page Path}}"),
+		"rows":  rows,
+	})
+	config := map[string]tftypes.Value{
+		"name":  testString("test-page-name"),
+		"input": testString("{{Page Path}}"),
+		"rows":  rows,
+	}
+
+	plan := planResourceChange(t, "gtm_lookup_table_variable", state, config)
+	assert.Empty(t, plan.requiresReplace)
+
+	config["regex"] = testObject(objectType.AttributeTypes["regex"].(tftypes.Object), nil)
+	plan = planResourceChange(t, "gtm_lookup_table_variable", state, config)
+	assert.True(t, plan.replaces("regex"))
+}
+
+func TestPlanCustomTemplateGalleryVersionChange(t *testing.T) {
+	galleryReferenceType := resourceObjectType(t, "gtm_custom_template").AttributeTypes["gallery_reference"].(tftypes.Object)
+	galleryReference := func(version string, computed bool) tftypes.Value {
+		attributes := map[string]tftypes.Value{
+			"owner":      testString("gtm-templates-simo-ahava"),
+			"repository": testString("consent-mode"),
+			"version":    testString(version),
+		}
+		if computed {
+			attributes["host"] = testString("github.com")
+			attributes["signature"] = testString("0b8c9e0d6a7f")
+			attributes["is_modified"] = tftypes.NewValue(tftypes.Bool, false)
+		}
+		return testObject(galleryReferenceType, attributes)
+	}
+	state := testState(map[string]tftypes.Value{
+		"id":                testString("21"),
+		"type":              testString("cvt_119458552_21"),
+		"name":              testString("Consent Mode"),
+		"template_data":     testString("___INFO___"),
+		"gallery_reference": galleryReference("5d5b6a3", true),
+	})
+	planned := func(plan testPlan, name string) tftypes.Value {
+		var attributes map[string]tftypes.Value
+		require.NoError(t, plan.planned["gallery_reference"].As(&attributes))
+		return attributes[name]
+	}
+
+	// A rename keeps the computed gallery reference attributes.
+	plan := planResourceChange(t, "gtm_custom_template", state, map[string]tftypes.Value{
+		"name":              testString("Consent Mode (Google tags)"),
+		"template_data":     testString("___INFO___"),
+		"gallery_reference": galleryReference("5d5b6a3", false),
+	})
+	assert.True(t, planned(plan, "host").Equal(testString("github.com")))
+	assert.True(t, planned(plan, "signature").Equal(testString("0b8c9e0d6a7f")))
+	assert.True(t, planned(plan, "is_modified").IsKnown())
+	assert.True(t, plan.planned["type"].Equal(testString("cvt_119458552_21")))
+
+	// Changed template data may change is_modified.
+	plan = planResourceChange(t, "gtm_custom_template", state, map[string]tftypes.Value{
+		"name":              testString("Consent Mode"),
+		"template_data":     testString("___INFO___ changed"),
+		"gallery_reference": galleryReference("5d5b6a3", false),
+	})
+	assert.True(t, planned(plan, "signature").IsKnown())
+	assert.False(t, planned(plan, "is_modified").IsKnown())
+
+	// A new gallery version is updated in place with a new signature.
+	plan = planResourceChange(t, "gtm_custom_template", state, map[string]tftypes.Value{
+		"name":              testString("Consent Mode"),
+		"template_data":     testString("___INFO___ v2"),
+		"gallery_reference": galleryReference("9f1e2d3", false),
+	})
+	assert.False(t, planned(plan, "signature").IsKnown())
+	assert.False(t, planned(plan, "is_modified").IsKnown())
+	assert.True(t, planned(plan, "version").Equal(testString("9f1e2d3")))
+	assert.Empty(t, plan.requiresReplace)
+}
+
+// testClient returns a client of the test workspace that needs no API call
+// while the variable names of the workspace are cached.
+func testClient() *api.ClientInWorkspace {
+	options := &api.ClientOptions{AccountId: "6105084028", ContainerId: "119458552"}
+
+	return &api.ClientInWorkspace{
+		Client:  &api.Client{Options: options},
+		Options: &api.ClientInWorkspaceOptions{ClientOptions: options, WorkspaceId: "12"},
+	}
+}
+
+// withVariableNames replaces the variable names of the workspaces by those of
+// a test workspace holding the given variables.
+func withVariableNames(t *testing.T, names ...string) {
+	cache := workspaceVariableNames
+	t.Cleanup(func() { workspaceVariableNames = cache })
+
+	workspaceVariableNames = &variableNameCache{names: map[string]map[string]bool{"6105084028/119458552/12": {}}}
+	for _, name := range names {
+		workspaceVariableNames.names["6105084028/119458552/12"][name] = true
+	}
+}
+
+// modifyPlan runs the plan modification of a resource configured with the
+// test client. Like the framework, it plans the prior values of computed
+// attributes that are not configured, and unknown values when creating.
+func modifyPlan(t *testing.T, r resource.ResourceWithModifyPlan, prior, config map[string]tftypes.Value) diag.Diagnostics {
+	ctx := context.Background()
+	r.(resource.ResourceWithConfigure).Configure(ctx, resource.ConfigureRequest{ProviderData: testClient()}, &resource.ConfigureResponse{})
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	s := schemaResp.Schema
+	objectType := s.Type().TerraformType(ctx).(tftypes.Object)
+
+	planned := map[string]tftypes.Value{}
+	for name, attribute := range s.Attributes {
+		switch value, ok := config[name]; {
+		case ok:
+			planned[name] = value
+		case prior != nil && attribute.IsComputed():
+			planned[name] = prior[name]
+		case attribute.IsComputed():
+			planned[name] = tftypes.NewValue(objectType.AttributeTypes[name], tftypes.UnknownValue)
+		}
+	}
+
+	state := tfsdk.State{Schema: s, Raw: tftypes.NewValue(objectType, nil)}
+	if prior != nil {
+		state.Raw = testObject(objectType, prior)
+	}
+	plan := tfsdk.Plan{Schema: s, Raw: testObject(objectType, planned)}
+
+	req := resource.ModifyPlanRequest{Config: tfsdk.Config{Schema: s, Raw: testObject(objectType, config)}, Plan: plan, State: state}
+	resp := resource.ModifyPlanResponse{Plan: plan}
+	r.ModifyPlan(ctx, req, &resp)
+
+	return resp.Diagnostics
+}
+
+func TestPlanTagCreateVariableReferences(t *testing.T) {
+	withVariableNames(t, "Page URL")
+
+	diags := modifyPlan(t, &tagResource{}, nil, map[string]tftypes.Value{
+		"name":       testString("test-html"),
+		"type":       testString("html"),
+		"parameters": testString(`{"html": "<p>{{Page URL}}</p>"}`),
+	})
+	assert.Empty(t, diags)
+
+	diags = modifyPlan(t, &tagResource{}, nil, map[string]tftypes.Value{
+		"name":       testString("test-html"),
+		"type":       testString("html"),
+		"parameters": testString(`{"html": "<p>{{Page URL}} {{Click ID}}</p>"}`),
+	})
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.SeverityWarning, diags[0].Severity())
+	assert.Equal(t, "Unresolved Variable Reference", diags[0].Summary())
+	assert.Contains(t, diags[0].Detail(), "{{Click ID}}")
+	assert.Equal(t, path.Root("parameters"), diags[0].(diag.DiagnosticWithPath).Path())
+}
+
+func TestPlanTagUpdateVariableReferences(t *testing.T) {
+	withVariableNames(t, "Page URL")
+	prior := testState(map[string]tftypes.Value{
+		"id":         testString("7"),
+		"name":       testString("test-html"),
+		"type":       testString("html"),
+		"parameters": testString(`{"html": "<p></p>"}`),
+	})
+
+	diags := modifyPlan(t, &tagResource{}, prior, map[string]tftypes.Value{
+		"name":       testString("test-html"),
+		"type":       testString("html"),
+		"parameters": testString(`{"html": "<p>{{Page URL}}</p>"}`),
+	})
+	assert.Empty(t, diags)
+
+	diags = modifyPlan(t, &tagResource{}, prior, map[string]tftypes.Value{
+		"name":       testString("test-html"),
+		"type":       testString("html"),
+		"parameters": testString(`{"html": "<p>{{Click ID}}</p>"}`),
+	})
+	require.Len(t, diags, 1)
+	assert.Equal(t, "Unresolved Variable Reference", diags[0].Summary())
+}
+
+func TestPlanTagVariableReferencesOfUnknownWorkspace(t *testing.T) {
+	withVariableNames(t)
+
+	diags := modifyPlan(t, &tagResource{}, nil, map[string]tftypes.Value{
+		"workspace_id": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"name":         testString("test-html"),
+		"type":         testString("html"),
+		"parameters":   testString(`{"html": "<p>{{Click ID}}</p>"}`),
+	})
+	assert.Empty(t, diags)
+}
+
+func TestPlanTagReferencesVariableOfSameApply(t *testing.T) {
+	withVariableNames(t)
+	tag := map[string]tftypes.Value{
+		"name":       testString("test-html"),
+		"type":       testString("html"),
+		"parameters": testString(`{"html": "<p>{{Click ID}}</p>"}`),
+	}
+
+	// The outcome does not depend on whether the variable is planned before
+	// or after the tag.
+	before := modifyPlan(t, &tagResource{}, nil, tag)
+	assert.Empty(t, modifyPlan(t, &variableResource{}, nil, map[string]tftypes.Value{
+		"name":       testString("Click ID"),
+		"type":       testString("c"),
+		"parameters": testString(`{"value": "42"}`),
+	}))
+	after := modifyPlan(t, &tagResource{}, nil, tag)
+
+	require.Len(t, before, 1)
+	assert.Equal(t, before, after)
+}
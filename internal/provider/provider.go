@@ -45,8 +45,17 @@ func (p *gtmProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *
 				Description: "GTM Container ID.",
 				Required:    true},
 			"workspace_name": schema.StringAttribute{
-				Description: "Workspace name.",
-				Required:    true},
+				Description: "Name of the default workspace. It is looked up on first use.",
+				Optional:    true},
+			"workspace_id": schema.StringAttribute{
+				Description: "ID of the default workspace, e.g. the id of a gtm_workspace resource. Takes precedence over workspace_name.",
+				Optional:    true},
+			"auto_create_workspace": schema.BoolAttribute{
+				Description: "Create the workspace named workspace_name on first use if it does not exist. Defaults to true. When false, a missing workspace is an error.",
+				Optional:    true},
+			"strict_variable_references": schema.BoolAttribute{
+				Description: "Make references to variables that are neither defined in the workspace nor enabled built-in variables an error instead of a warning when planning. Variables created in the same apply do not exist while planning and are reported too, so do not enable this while creating variables and the resources referring to them in one apply.",
+				Optional:    true},
 			"max_api_queries_per_minute": schema.Int64Attribute{
 				Description: "Maximum number of API queries per minute.",
 				Optional:    true},
@@ -55,11 +64,14 @@ func (p *gtmProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *
 }
 
 type gtmProviderModel struct {
-	CredentialFile         types.String `tfsdk:"credential_file"`
-	AccountId              types.String `tfsdk:"account_id"`
-	ContainerId            types.String `tfsdk:"container_id"`
-	WorkspaceName          types.String `tfsdk:"workspace_name"`
-	MaxApiQueriesPerMinute types.Int64  `tfsdk:"max_api_queries_per_minute"`
+	CredentialFile           types.String `tfsdk:"credential_file"`
+	AccountId                types.String `tfsdk:"account_id"`
+	ContainerId              types.String `tfsdk:"container_id"`
+	WorkspaceName            types.String `tfsdk:"workspace_name"`
+	WorkspaceId              types.String `tfsdk:"workspace_id"`
+	AutoCreateWorkspace      types.Bool   `tfsdk:"auto_create_workspace"`
+	StrictVariableReferences types.Bool   `tfsdk:"strict_variable_references"`
+	MaxApiQueriesPerMinute   types.Int64  `tfsdk:"max_api_queries_per_minute"`
 }
 
 // Configure prepares an API client for data sources and resources.
@@ -81,6 +93,8 @@ func (p *gtmProvider) Configure(ctx context.Context, req provider.ConfigureReque
 		waitingTimeBeforeEachQuery = time.Duration(int64(time.Minute) / maxApiQueriesPerMinute)
 	}
 
+	// The workspace is resolved lazily by the client, so that configuring the
+	// provider makes no API queries and plans have no side effects.
 	client, err := api.NewClientInWorkspace(&api.ClientInWorkspaceOptions{
 		ClientOptions: &api.ClientOptions{
 			CredentialFile:             config.CredentialFile.ValueString(),
@@ -88,7 +102,10 @@ func (p *gtmProvider) Configure(ctx context.Context, req provider.ConfigureReque
 			ContainerId:                config.ContainerId.ValueString(),
 			WaitingTimeBeforeEachQuery: waitingTimeBeforeEachQuery,
 		},
-		WorkspaceName: config.WorkspaceName.ValueString(),
+		WorkspaceName:            config.WorkspaceName.ValueString(),
+		WorkspaceId:              config.WorkspaceId.ValueString(),
+		AutoCreateWorkspace:      config.AutoCreateWorkspace.IsNull() || config.AutoCreateWorkspace.ValueBool(),
+		StrictVariableReferences: config.StrictVariableReferences.ValueBool(),
 	})
 	if err != nil {
 		resp.Diagnostics.AddError("Unable to Create GTM Client", err.Error())
@@ -100,15 +117,31 @@ func (p *gtmProvider) Configure(ctx context.Context, req provider.ConfigureReque
 
 // DataSources defines the data sources implemented in the provider.
 func (p *gtmProvider) DataSources(_ context.Context) []func() datasource.DataSource {
-	return nil
+	return []func() datasource.DataSource{
+		NewContainerDataSource,
+		NewUserPermissionsDataSource,
+		NewDestinationsDataSource,
+	}
 }
 
 // Resources defines the resources implemented in the provider.
 func (p *gtmProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
+		NewContainerResource,
+		NewEnvironmentResource,
+		NewUserPermissionResource,
 		NewWorkspaceResource,
 		NewTagResource,
 		NewVariableResource,
 		NewTriggerResource,
+		NewServerClientResource,
+		NewTransformationResource,
+		NewZoneResource,
+		NewCustomTemplateResource,
+		NewGtagConfigResource,
+		NewDestinationResource,
+		NewGa4ConfigTagResource,
+		NewGa4EventTagResource,
+		NewLookupTableVariableResource,
 	}
 }
@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"terraform-provider-google-tag-manager/internal/api"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"google.golang.org/api/tagmanager/v2"
+)
+
+func NewServerClientResource() resource.Resource {
+	return &serverEntityResource{kind: serverClientKind}
+}
+
+// serverClientKind manages the clients of server containers.
+var serverClientKind = serverEntityKind{
+	typeName: "client",
+	noun:     "client",
+	title:    "Client",
+	priority: true,
+
+	create: func(client *api.ClientInWorkspace, entity *serverEntity) (*serverEntity, error) {
+		serverClient, err := client.CreateServerClient(toApiServerClient(entity))
+		if err != nil {
+			return nil, err
+		}
+		return toServerEntityFromClient(serverClient), nil
+	},
+	read: func(client *api.ClientInWorkspace, id string) (*serverEntity, error) {
+		serverClient, err := client.ServerClient(id)
+		if err != nil {
+			return nil, err
+		}
+		return toServerEntityFromClient(serverClient), nil
+	},
+	update: func(client *api.ClientInWorkspace, id string, entity *serverEntity) (*serverEntity, error) {
+		serverClient, err := client.UpdateServerClient(id, toApiServerClient(entity))
+		if err != nil {
+			return nil, err
+		}
+		return toServerEntityFromClient(serverClient), nil
+	},
+	delete: func(client *api.ClientInWorkspace, id string) error {
+		return client.DeleteServerClient(id)
+	},
+}
+
+func toServerEntityFromClient(serverClient *tagmanager.Client) *serverEntity {
+	return &serverEntity{
+		AccountId:   serverClient.AccountId,
+		ContainerId: serverClient.ContainerId,
+		WorkspaceId: serverClient.WorkspaceId,
+		Id:          serverClient.ClientId,
+		Name:        serverClient.Name,
+		Type:        serverClient.Type,
+		Notes:       serverClient.Notes,
+		Priority:    serverClient.Priority,
+		Parameter:   serverClient.Parameter,
+	}
+}
+
+func toApiServerClient(entity *serverEntity) *tagmanager.Client {
+	return &tagmanager.Client{
+		Name:      entity.Name,
+		Type:      entity.Type,
+		ClientId:  entity.Id,
+		Notes:     entity.Notes,
+		Priority:  entity.Priority,
+		Parameter: entity.Parameter,
+	}
+}
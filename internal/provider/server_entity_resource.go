@@ -0,0 +1,324 @@
+package provider
+
+import (
+	"context"
+	"terraform-provider-google-tag-manager/internal/api"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/api/tagmanager/v2"
+)
+
+var (
+	_ resource.ResourceWithConfigure      = &serverEntityResource{}
+	_ resource.ResourceWithValidateConfig = &serverEntityResource{}
+	_ resource.ResourceWithModifyPlan     = &serverEntityResource{}
+	_ resource.ResourceWithUpgradeState   = &serverEntityResource{}
+)
+
+// serverEntity holds the fields that clients and transformations of server
+// containers share. Transformations have no priority.
+type serverEntity struct {
+	AccountId   string
+	ContainerId string
+	WorkspaceId string
+	Id          string
+	Name        string
+	Type        string
+	Notes       string
+	Priority    int64
+	Parameter   []*tagmanager.Parameter
+}
+
+// serverEntityKind describes the clients or the transformations of server
+// containers, which are managed by the same resource implementation.
+type serverEntityKind struct {
+	// typeName is the suffix of the resource type name.
+	typeName string
+	// noun names the entity in descriptions, title in diagnostics.
+	noun  string
+	title string
+	// priority tells whether the entities have a priority attribute.
+	priority bool
+
+	create func(client *api.ClientInWorkspace, entity *serverEntity) (*serverEntity, error)
+	read   func(client *api.ClientInWorkspace, id string) (*serverEntity, error)
+	update func(client *api.ClientInWorkspace, id string, entity *serverEntity) (*serverEntity, error)
+	delete func(client *api.ClientInWorkspace, id string) error
+}
+
+type serverEntityResource struct {
+	kind   serverEntityKind
+	client *api.ClientInWorkspace
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *serverEntityResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(*api.ClientInWorkspace)
+}
+
+// Metadata returns the resource type name.
+func (r *serverEntityResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + r.kind.typeName
+}
+
+// serverEntitySchemaAttributes returns the attributes of the entities of a
+// kind.
+func serverEntitySchemaAttributes(kind serverEntityKind) map[string]schema.Attribute {
+	attributes := map[string]schema.Attribute{
+		"account_id":   accountIdSchema,
+		"container_id": containerIdSchema,
+		"workspace_id": workspaceIdSchema,
+		"name": schema.StringAttribute{
+			Description: "The name of the " + kind.noun + ".",
+			Required:    true,
+		},
+		"type": schema.StringAttribute{
+			Description:   "The type of the " + kind.noun + ". Changing it recreates the " + kind.noun + ".",
+			Required:      true,
+			PlanModifiers: immutablePlanModifiers,
+		},
+		"id": schema.StringAttribute{
+			Description:   "The ID of the " + kind.noun + ".",
+			Computed:      true,
+			PlanModifiers: idPlanModifiers,
+		},
+		"notes": schema.StringAttribute{
+			Description: "The notes of the " + kind.noun + ".",
+			Optional:    true,
+		},
+		"parameter":      parameterSchema,
+		"parameter_json": parameterJsonSchema,
+		"parameters":     parametersSchema,
+	}
+
+	if kind.priority {
+		attributes["priority"] = schema.Int64Attribute{
+			Description: "The priority of the " + kind.noun + ". " + kind.title + "s with a higher priority are evaluated first.",
+			Optional:    true,
+		}
+	}
+
+	return attributes
+}
+
+// Schema defines the schema for the resource.
+func (r *serverEntityResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version:    parameterSchemaVersion,
+		Attributes: serverEntitySchemaAttributes(r.kind),
+	}
+}
+
+// UpgradeState upgrades the state from prior schema versions.
+func (r *serverEntityResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	newModel := func() interface{} { return &resourceServerEntityModel{} }
+	if !r.kind.priority {
+		newModel = func() interface{} { return &resourceTransformationModel{} }
+	}
+
+	return map[int64]resource.StateUpgrader{
+		0: parameterStateUpgrader(serverEntitySchemaAttributes(r.kind), newModel),
+	}
+}
+
+// ValidateConfig checks the parameters of the entity.
+func (r *serverEntityResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(validateConfigParameters(ctx, req.Config)...)
+}
+
+// ModifyPlan checks the variable references of the entity against the
+// workspace.
+func (r *serverEntityResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resp.Diagnostics.Append(checkPlanVariableReferences(ctx, r.client, req)...)
+}
+
+// resourceServerEntityModel is the model of clients and of transformations,
+// whose priority stays null.
+type resourceServerEntityModel struct {
+	AccountId     types.String             `tfsdk:"account_id"`
+	ContainerId   types.String             `tfsdk:"container_id"`
+	WorkspaceId   types.String             `tfsdk:"workspace_id"`
+	Name          types.String             `tfsdk:"name"`
+	Type          types.String             `tfsdk:"type"`
+	Id            types.String             `tfsdk:"id"`
+	Notes         types.String             `tfsdk:"notes"`
+	Priority      types.Int64              `tfsdk:"priority"`
+	Parameter     []ResourceParameterModel `tfsdk:"parameter"`
+	ParameterJson types.String             `tfsdk:"parameter_json"`
+	Parameters    types.String             `tfsdk:"parameters"`
+}
+
+// Equal compares the two models and returns true if they are equal.
+func (m resourceServerEntityModel) Equal(o resourceServerEntityModel) bool {
+	if (!m.AccountId.IsUnknown() && !m.AccountId.Equal(o.AccountId)) ||
+		(!m.ContainerId.IsUnknown() && !m.ContainerId.Equal(o.ContainerId)) ||
+		(!m.WorkspaceId.IsUnknown() && !m.WorkspaceId.Equal(o.WorkspaceId)) ||
+		!m.Name.Equal(o.Name) ||
+		!m.Type.Equal(o.Type) ||
+		(!m.Id.IsUnknown() && !m.Id.Equal(o.Id)) ||
+		!m.Notes.Equal(o.Notes) ||
+		!m.Priority.Equal(o.Priority) ||
+		!m.ParameterJson.Equal(o.ParameterJson) ||
+		!m.Parameters.Equal(o.Parameters) {
+		return false
+	}
+
+	return parameterSetsEqual(m.Parameter, o.Parameter)
+}
+
+func toResourceServerEntity(entity *serverEntity, prior resourceServerEntityModel) resourceServerEntityModel {
+	parameter, parameterJson, parameters := toResourceParameters(entity.Parameter, nil, prior.Parameter, prior.ParameterJson, prior.Parameters)
+
+	return resourceServerEntityModel{
+		AccountId:     types.StringValue(entity.AccountId),
+		ContainerId:   types.StringValue(entity.ContainerId),
+		WorkspaceId:   types.StringValue(entity.WorkspaceId),
+		Name:          types.StringValue(entity.Name),
+		Type:          types.StringValue(entity.Type),
+		Id:            types.StringValue(entity.Id),
+		Notes:         priorStringValue(entity.Notes, prior.Notes),
+		Priority:      priorInt64Value(entity.Priority, prior.Priority),
+		Parameter:     parameter,
+		ParameterJson: parameterJson,
+		Parameters:    parameters,
+	}
+}
+
+func toApiServerEntity(resource resourceServerEntityModel) *serverEntity {
+	return &serverEntity{
+		Id:        resource.Id.ValueString(),
+		Name:      resource.Name.ValueString(),
+		Type:      resource.Type.ValueString(),
+		Notes:     resource.Notes.ValueString(),
+		Priority:  resource.Priority.ValueInt64(),
+		Parameter: toApiParameters(resource.Parameter, resource.ParameterJson, resource.Parameters),
+	}
+}
+
+// getModel reads the model with get, e.g. the Get of a plan or state. The
+// priority of a kind without priority is null.
+func (r *serverEntityResource) getModel(ctx context.Context, get func(context.Context, interface{}) diag.Diagnostics) (resourceServerEntityModel, diag.Diagnostics) {
+	if r.kind.priority {
+		var model resourceServerEntityModel
+		diags := get(ctx, &model)
+		return model, diags
+	}
+
+	var model resourceTransformationModel
+	diags := get(ctx, &model)
+	return model.serverEntityModel(), diags
+}
+
+// setModel writes the model with set, e.g. the Set of a state, leaving out
+// the priority of a kind without priority.
+func (r *serverEntityResource) setModel(ctx context.Context, set func(context.Context, interface{}) diag.Diagnostics, model resourceServerEntityModel) diag.Diagnostics {
+	if r.kind.priority {
+		return set(ctx, model)
+	}
+
+	return set(ctx, toResourceTransformationModel(model))
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *serverEntityResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	plan, diags := r.getModel(ctx, req.Plan.Get)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := clientInWorkspace(ctx, r.client, plan.AccountId, plan.ContainerId, plan.WorkspaceId)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Creating "+r.kind.title, err.Error())
+		return
+	}
+
+	entity, err := r.kind.create(client, toApiServerEntity(plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Creating "+r.kind.title, err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(r.setModel(ctx, resp.State.Set, toResourceServerEntity(entity, plan))...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *serverEntityResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	state, diags := r.getModel(ctx, req.State.Get)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := clientInWorkspace(ctx, r.client, state.AccountId, state.ContainerId, state.WorkspaceId)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading "+r.kind.title, err.Error())
+		return
+	}
+
+	entity, err := r.kind.read(client, state.Id.ValueString())
+	if err == api.ErrNotExist {
+		resp.State.RemoveResource(ctx)
+		return
+	} else if err != nil {
+		resp.Diagnostics.AddError("Error Reading "+r.kind.title, err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(r.setModel(ctx, resp.State.Set, toResourceServerEntity(entity, state))...)
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *serverEntityResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	plan, diags := r.getModel(ctx, req.Plan.Get)
+	resp.Diagnostics.Append(diags...)
+
+	state, diags := r.getModel(ctx, req.State.Get)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := clientInWorkspace(ctx, r.client, state.AccountId, state.ContainerId, state.WorkspaceId)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Updating "+r.kind.title, err.Error())
+		return
+	}
+
+	entity, err := r.kind.update(client, state.Id.ValueString(), toApiServerEntity(plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Updating "+r.kind.title, err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(r.setModel(ctx, resp.State.Set, toResourceServerEntity(entity, plan))...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *serverEntityResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	state, diags := r.getModel(ctx, req.State.Get)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := clientInWorkspace(ctx, r.client, state.AccountId, state.ContainerId, state.WorkspaceId)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Deleting "+r.kind.title, err.Error())
+		return
+	}
+
+	err = r.kind.delete(client, state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error Deleting "+r.kind.title, err.Error())
+		return
+	}
+}
@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToResourceServerEntityKeepsZeroPriority(t *testing.T) {
+	entity := &serverEntity{Id: "3", Name: "test-client", Type: "gaaw_client"}
+
+	model := toResourceServerEntity(entity, resourceServerEntityModel{Priority: types.Int64Value(0)})
+	assert.Equal(t, types.Int64Value(0), model.Priority)
+
+	model = toResourceServerEntity(entity, resourceServerEntityModel{Priority: types.Int64Null()})
+	assert.True(t, model.Priority.IsNull())
+
+	entity.Priority = 5
+	model = toResourceServerEntity(entity, resourceServerEntityModel{Priority: types.Int64Value(0)})
+	assert.Equal(t, types.Int64Value(5), model.Priority)
+}
+
+func TestServerEntityResourceStateWithoutPriority(t *testing.T) {
+	ctx := context.Background()
+	r := NewTransformationResource().(*serverEntityResource)
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+	assert.NotContains(t, schemaResp.Schema.Attributes, "priority")
+
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	model := toResourceServerEntity(&serverEntity{
+		AccountId:   "6105084028",
+		ContainerId: "119458552",
+		WorkspaceId: "12",
+		Id:          "4",
+		Name:        "test-transformation",
+		Type:        "tf_allow_params",
+	}, resourceServerEntityModel{})
+
+	diags := r.setModel(ctx, state.Set, model)
+	require.False(t, diags.HasError(), diags)
+
+	read, diags := r.getModel(ctx, state.Get)
+	require.False(t, diags.HasError(), diags)
+	assert.True(t, read.Priority.IsNull())
+	assert.True(t, model.Equal(read))
+}
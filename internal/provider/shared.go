@@ -1,11 +1,81 @@
 package provider
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"terraform-provider-google-tag-manager/internal/api"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"google.golang.org/api/tagmanager/v2"
 )
 
+// locationPlanModifiers keep the location of an existing resource stable and
+// recreate the resource when it is moved elsewhere.
+var locationPlanModifiers = []planmodifier.String{
+	stringplanmodifier.UseStateForUnknown(),
+	stringplanmodifier.RequiresReplace(),
+}
+
+// idPlanModifiers keep the ID of an existing resource known while planning, so
+// that updates show the attributes that actually change.
+var idPlanModifiers = []planmodifier.String{
+	stringplanmodifier.UseStateForUnknown(),
+}
+
+// immutablePlanModifiers recreate the resource when an attribute changes that
+// the API cannot update in place.
+var immutablePlanModifiers = []planmodifier.String{
+	stringplanmodifier.RequiresReplace(),
+}
+
+var accountIdSchema = schema.StringAttribute{
+	Description:   "GTM Account ID. Defaults to the account_id of the provider.",
+	Optional:      true,
+	Computed:      true,
+	PlanModifiers: locationPlanModifiers,
+}
+
+var containerIdSchema = schema.StringAttribute{
+	Description:   "GTM Container ID. Defaults to the container_id of the provider.",
+	Optional:      true,
+	Computed:      true,
+	PlanModifiers: locationPlanModifiers,
+}
+
+var workspaceIdSchema = schema.StringAttribute{
+	Description:   "GTM Workspace ID, e.g. the id of a gtm_workspace resource. Defaults to the workspace of the provider. Required when account_id or container_id is overridden.",
+	Optional:      true,
+	Computed:      true,
+	PlanModifiers: locationPlanModifiers,
+}
+
+// clientInWorkspace returns a client bound to the workspace given by the
+// location attributes of a resource, falling back to the provider defaults.
+// Its API calls are logged with the loggers of ctx, the context of the
+// operation.
+func clientInWorkspace(ctx context.Context, client *api.ClientInWorkspace, accountId, containerId, workspaceId types.String) (*api.ClientInWorkspace, error) {
+	client, err := client.InWorkspace(accountId.ValueString(), containerId.ValueString(), workspaceId.ValueString())
+	if err != nil {
+		return nil, err
+	}
+
+	return client.WithContext(ctx), nil
+}
+
 var parameterSchema = buildParameterSchema()
 
 var conditionSchema = schema.ListNestedAttribute{
@@ -20,37 +90,54 @@ var conditionSchema = schema.ListNestedAttribute{
 	},
 }
 
-func wrapParameterSchema(list schema.ListNestedAttribute) schema.ListNestedAttribute {
-	return schema.ListNestedAttribute{
-		Optional: true,
-		NestedObject: schema.NestedAttributeObject{
-			Attributes: map[string]schema.Attribute{
-				"key": schema.StringAttribute{
-					Description: "Parameter key.",
-					Optional:    true},
-				"type": schema.StringAttribute{
-					Description: "Parameter type.",
-					Required:    true},
-				"value": schema.StringAttribute{
-					Description: "Parameter value.",
-					Optional:    true},
-				"list": list,
-				"map":  list,
-			},
+// parameterObjectSchema returns the attributes of a parameter whose list and
+// map children have the given schemas.
+func parameterObjectSchema(list schema.ListNestedAttribute, mmap schema.SetNestedAttribute) schema.NestedAttributeObject {
+	return schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"key": schema.StringAttribute{
+				Description: "Parameter key.",
+				Optional:    true},
+			"type": schema.StringAttribute{
+				Description: "Parameter type.",
+				Required:    true},
+			"value": schema.StringAttribute{
+				Description: "Parameter value.",
+				Optional:    true},
+			"list": list,
+			"map":  mmap,
 		},
 	}
 }
 
-func buildParameterSchema() schema.ListNestedAttribute {
-	var s = schema.ListNestedAttribute{
+// parameterSchemaDepth is the number of parameter levels, including the top
+// level, that the parameter attribute can express. Deeper parameters are only
+// expressible with parameter_json.
+const parameterSchemaDepth = 3
+
+// buildParameterSchema returns the schema of nested parameters. Top-level
+// parameters and map entries are identified by their keys, so they are sets
+// and the order in which the API returns them does not matter. List items keep
+// their order.
+func buildParameterSchema() schema.SetNestedAttribute {
+	var list = schema.ListNestedAttribute{
+		Description: "Parameters.",
+		Optional:    true, NestedObject: schema.NestedAttributeObject{}}
+	var mmap = schema.SetNestedAttribute{
 		Description: "Parameters.",
 		Optional:    true, NestedObject: schema.NestedAttributeObject{}}
 
-	for i := 0; i < 3; i++ {
-		s = wrapParameterSchema(s)
+	for i := 0; i < parameterSchemaDepth-1; i++ {
+		object := parameterObjectSchema(list, mmap)
+		list = schema.ListNestedAttribute{Optional: true, NestedObject: object}
+		mmap = schema.SetNestedAttribute{Optional: true, NestedObject: object}
 	}
 
-	return s
+	return schema.SetNestedAttribute{
+		Description:  "Parameters, identified by their keys. The entries of map parameters are identified by their keys too, while list items keep their order.",
+		Optional:     true,
+		NestedObject: parameterObjectSchema(list, mmap),
+	}
 }
 
 type ResourceParameterModel struct {
@@ -61,12 +148,14 @@ type ResourceParameterModel struct {
 	Map   []ResourceParameterModel `tfsdk:"map"`
 }
 
+// Equal compares two parameters. Map entries are compared by key, list items
+// in order.
 func (r *ResourceParameterModel) Equal(o ResourceParameterModel) bool {
 	if !r.Key.Equal(o.Key) ||
 		!r.Type.Equal(o.Type) ||
 		!r.Value.Equal(o.Value) ||
 		len(r.List) != len(o.List) ||
-		len(r.Map) != len(o.Map) {
+		!parameterSetsEqual(r.Map, o.Map) {
 		return false
 	}
 
@@ -76,8 +165,27 @@ func (r *ResourceParameterModel) Equal(o ResourceParameterModel) bool {
 		}
 	}
 
-	for i := 0; i < len(r.Map); i++ {
-		if !r.Map[i].Equal(o.Map[i]) {
+	return true
+}
+
+// parameterSetsEqual compares two keyed parameter lists regardless of their
+// order.
+func parameterSetsEqual(a, b []ResourceParameterModel) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	used := make([]bool, len(b))
+	for _, p := range a {
+		found := false
+		for i, q := range b {
+			if !used[i] && p.Equal(q) {
+				used[i], found = true, true
+				break
+			}
+		}
+
+		if !found {
 			return false
 		}
 	}
@@ -137,6 +245,565 @@ func toResourceParameter(parameter []*tagmanager.Parameter) []ResourceParameterM
 	return resourceParameter
 }
 
+var parameterJsonSchema = schema.StringAttribute{
+	Description: "Parameters as a JSON list of API parameter objects with key, type, value, list and map fields, e.g. jsonencode([...]). Unlike parameter, it supports any nesting depth. Conflicts with parameter and parameters.",
+	Optional:    true,
+	Validators:  []validator.String{parameterStringValidator{decodeParameterJson}},
+}
+
+var parametersSchema = schema.StringAttribute{
+	Description: "Parameters as a JSON object, e.g. jsonencode({ eventName = \"purchase\" }). Strings become template, booleans boolean, numbers integer, lists list and objects map parameters. Conflicts with parameter and parameter_json.",
+	Optional:    true,
+	Validators:  []validator.String{parameterStringValidator{decodeParametersObject}},
+}
+
+// parameterAttributes are the mutually exclusive attributes that set the
+// parameters of a resource.
+var parameterAttributes = []string{"parameter", "parameter_json", "parameters"}
+
+// parameterStringValidator checks that a JSON encoded parameter attribute
+// decodes into API parameters and that no other parameter attribute is set.
+type parameterStringValidator struct {
+	decode func(string) ([]*tagmanager.Parameter, error)
+}
+
+func (v parameterStringValidator) Description(_ context.Context) string {
+	return "value must decode into parameters and no other parameter attribute must be set"
+}
+
+func (v parameterStringValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v parameterStringValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := v.decode(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Parameters", err.Error())
+	}
+
+	for _, name := range setSiblingAttributes(ctx, req.Config, req.Path, parameterAttributes) {
+		resp.Diagnostics.AddAttributeError(req.Path, "Conflicting Parameters", fmt.Sprintf("Cannot be set together with %s.", name))
+	}
+}
+
+// setSiblingAttributes returns the names of the attributes next to p that
+// are set in the configuration, leaving out p itself.
+func setSiblingAttributes(ctx context.Context, config tfsdk.Config, p path.Path, names []string) []string {
+	var set []string
+
+	for _, name := range names {
+		other := p.ParentPath().AtName(name)
+		if other.Equal(p) {
+			continue
+		}
+
+		var value attr.Value
+		diags := config.GetAttribute(ctx, other, &value)
+		if diags.HasError() {
+			continue
+		}
+
+		if value != nil && !value.IsNull() && !value.IsUnknown() {
+			set = append(set, name)
+		}
+	}
+
+	return set
+}
+
+// stringOneOfValidator checks that a string is one of the given values.
+type stringOneOfValidator struct {
+	values []string
+}
+
+func (v stringOneOfValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("value must be one of: %s", strings.Join(v.values, ", "))
+}
+
+func (v stringOneOfValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v stringOneOfValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	for _, value := range v.values {
+		if req.ConfigValue.ValueString() == value {
+			return
+		}
+	}
+
+	resp.Diagnostics.AddAttributeError(req.Path, "Invalid Value", fmt.Sprintf("%q is not valid, %s.", req.ConfigValue.ValueString(), v.Description(ctx)))
+}
+
+var errParameterType = errors.New("every parameter must have a type")
+
+func decodeParameterJson(s string) ([]*tagmanager.Parameter, error) {
+	var parameter []*tagmanager.Parameter
+	if err := json.Unmarshal([]byte(s), &parameter); err != nil {
+		return nil, err
+	}
+
+	var check func([]*tagmanager.Parameter) error
+	check = func(parameter []*tagmanager.Parameter) error {
+		for _, p := range parameter {
+			if p == nil || p.Type == "" {
+				return errParameterType
+			}
+			if err := check(p.List); err != nil {
+				return err
+			}
+			if err := check(p.Map); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return parameter, check(parameter)
+}
+
+func encodeParameterJson(parameter []*tagmanager.Parameter) string {
+	if parameter == nil {
+		parameter = []*tagmanager.Parameter{}
+	}
+
+	b, _ := json.Marshal(parameter)
+	return string(b)
+}
+
+// decodeParametersObject converts a JSON object into parameters, inferring
+// the parameter types from the JSON types. Null values are left out.
+func decodeParametersObject(s string) ([]*tagmanager.Parameter, error) {
+	d := json.NewDecoder(strings.NewReader(s))
+	d.UseNumber()
+
+	var object map[string]interface{}
+	if err := d.Decode(&object); err != nil {
+		return nil, err
+	}
+
+	if object == nil {
+		return nil, errors.New("parameters must be a JSON object")
+	}
+
+	return toApiParameterMap(object)
+}
+
+func toApiParameterMap(object map[string]interface{}) ([]*tagmanager.Parameter, error) {
+	keys := make([]string, 0, len(object))
+	for k := range object {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var parameter []*tagmanager.Parameter
+	for _, k := range keys {
+		p, err := toApiParameterValue(k, object[k])
+		if err != nil {
+			return nil, err
+		}
+
+		if p != nil {
+			p.Key = k
+			parameter = append(parameter, p)
+		}
+	}
+
+	return parameter, nil
+}
+
+func toApiParameterValue(key string, value interface{}) (*tagmanager.Parameter, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return &tagmanager.Parameter{Type: "template", Value: v}, nil
+	case bool:
+		return &tagmanager.Parameter{Type: "boolean", Value: strconv.FormatBool(v)}, nil
+	case json.Number:
+		if _, err := strconv.ParseInt(v.String(), 10, 64); err != nil {
+			return nil, fmt.Errorf("%s: %s is not an integer", key, v)
+		}
+		return &tagmanager.Parameter{Type: "integer", Value: v.String()}, nil
+	case []interface{}:
+		list := []*tagmanager.Parameter{}
+		for _, item := range v {
+			p, err := toApiParameterValue(key, item)
+			if err != nil {
+				return nil, err
+			}
+			if p != nil {
+				list = append(list, p)
+			}
+		}
+		return &tagmanager.Parameter{Type: "list", List: list}, nil
+	case map[string]interface{}:
+		mmap, err := toApiParameterMap(v)
+		if err != nil {
+			return nil, err
+		}
+		return &tagmanager.Parameter{Type: "map", Map: mmap}, nil
+	default:
+		return nil, fmt.Errorf("%s: unsupported value %v", key, v)
+	}
+}
+
+// encodeParametersObject is the inverse of decodeParametersObject. It returns
+// false when the parameters use types or keys that a JSON object cannot
+// express.
+func encodeParametersObject(parameter []*tagmanager.Parameter) (string, bool) {
+	object, ok := toResourceParameterMap(parameter)
+	if !ok {
+		return "", false
+	}
+
+	b, err := json.Marshal(object)
+	return string(b), err == nil
+}
+
+func toResourceParameterMap(parameter []*tagmanager.Parameter) (map[string]interface{}, bool) {
+	object := make(map[string]interface{}, len(parameter))
+
+	for _, p := range parameter {
+		if _, exists := object[p.Key]; exists || p.Key == "" {
+			return nil, false
+		}
+
+		v, ok := toResourceParameterValue(p)
+		if !ok {
+			return nil, false
+		}
+		object[p.Key] = v
+	}
+
+	return object, true
+}
+
+func toResourceParameterValue(p *tagmanager.Parameter) (interface{}, bool) {
+	switch p.Type {
+	case "template":
+		return p.Value, true
+	case "boolean":
+		b, err := strconv.ParseBool(p.Value)
+		return b, err == nil
+	case "integer":
+		_, err := strconv.ParseInt(p.Value, 10, 64)
+		return json.Number(p.Value), err == nil
+	case "list":
+		list := make([]interface{}, len(p.List))
+		for i, item := range p.List {
+			if item.Key != "" {
+				return nil, false
+			}
+
+			v, ok := toResourceParameterValue(item)
+			if !ok {
+				return nil, false
+			}
+			list[i] = v
+		}
+		return list, true
+	case "map":
+		return toResourceParameterMap(p.Map)
+	default:
+		return nil, false
+	}
+}
+
+// parameterDepth returns the number of parameter levels, including the top
+// level.
+func parameterDepth(parameter []*tagmanager.Parameter) int {
+	depth := 0
+
+	for _, p := range parameter {
+		if d := 1 + parameterDepth(p.List); d > depth {
+			depth = d
+		}
+		if d := 1 + parameterDepth(p.Map); d > depth {
+			depth = d
+		}
+	}
+
+	return depth
+}
+
+// toApiParameters returns the API parameters of a resource from whichever of
+// parameter, parameter_json and parameters is set. The JSON attributes were
+// already checked by parameterStringValidator at plan time.
+func toApiParameters(resourceParameter []ResourceParameterModel, parameterJson, parameters types.String) []*tagmanager.Parameter {
+	var parameter []*tagmanager.Parameter
+
+	switch {
+	case !parameters.IsNull() && !parameters.IsUnknown():
+		parameter, _ = decodeParametersObject(parameters.ValueString())
+	case !parameterJson.IsNull() && !parameterJson.IsUnknown():
+		parameter, _ = decodeParameterJson(parameterJson.ValueString())
+	default:
+		parameter = toApiParameter(resourceParameter)
+	}
+
+	return parameter
+}
+
+// toResourceParameters converts API parameters back into the attribute that
+// the prior value used. parameters falls back to parameter when the API
+// returns parameters that a JSON object cannot express, and parameter falls
+// back to parameter_json when they are nested too deep. A prior value that
+// is equal for the API is kept as written. Parameters the API added with
+// their defaults, which the prior value does not set, are left out.
+func toResourceParameters(parameter []*tagmanager.Parameter, defaults map[string]string, priorParameter []ResourceParameterModel, priorJson, priorParameters types.String) ([]ResourceParameterModel, types.String, types.String) {
+	parameter = withoutServerDefaults(parameter, defaults, toApiParameters(priorParameter, priorJson, priorParameters))
+
+	if !priorParameters.IsNull() {
+		if encoded, ok := encodeParametersObject(parameter); ok {
+			if prior, err := decodeParametersObject(priorParameters.ValueString()); err == nil {
+				if same, _ := encodeParametersObject(prior); same == encoded {
+					return nil, types.StringNull(), priorParameters
+				}
+			}
+
+			return nil, types.StringNull(), types.StringValue(encoded)
+		}
+	}
+
+	if priorJson.IsNull() && parameterDepth(parameter) <= parameterSchemaDepth {
+		return keepPriorParameters(toResourceParameter(parameter), priorParameter), types.StringNull(), types.StringNull()
+	}
+
+	// The API may return the parameters and map entries in another order.
+	if prior, err := decodeParameterJson(priorJson.ValueString()); err == nil && encodeParameterJson(canonicalParameters(prior)) == encodeParameterJson(canonicalParameters(parameter)) {
+		return nil, priorJson, types.StringNull()
+	}
+
+	return nil, types.StringValue(encodeParameterJson(parameter)), types.StringNull()
+}
+
+// withoutServerDefaults returns the parameters without the top-level ones
+// that are not configured and either have the server default of their key or
+// are empty templates, which the API adds for some types.
+func withoutServerDefaults(parameter []*tagmanager.Parameter, defaults map[string]string, configured []*tagmanager.Parameter) []*tagmanager.Parameter {
+	var kept []*tagmanager.Parameter
+
+	for _, p := range parameter {
+		if findParameter(configured, p.Key) == nil && p.List == nil && p.Map == nil {
+			if value, ok := defaults[p.Key]; ok && value == p.Value {
+				continue
+			}
+			if p.Type == "template" && p.Value == "" {
+				continue
+			}
+		}
+
+		kept = append(kept, p)
+	}
+
+	return kept
+}
+
+// keepPriorParameters replaces the parameters that are equal for the API to a
+// prior parameter, e.g. an empty value that the API returns as no value, with
+// the prior parameter.
+func keepPriorParameters(parameter []ResourceParameterModel, prior []ResourceParameterModel) []ResourceParameterModel {
+	for i, p := range parameter {
+		encoded := encodeParameterJson(toApiParameter([]ResourceParameterModel{p}))
+
+		for _, q := range prior {
+			if encodeParameterJson(toApiParameter([]ResourceParameterModel{q})) == encoded {
+				parameter[i] = q
+				break
+			}
+		}
+	}
+
+	return parameter
+}
+
+var apiJsonSchema = schema.StringAttribute{
+	Description: "The object that the provider sends to the API for the resource, as canonical JSON without its ID. Top-level parameters and map entries are sorted by key. Useful to review or debug the parameter conversion.",
+	Computed:    true,
+}
+
+// apiJsonValue renders an API object as indented JSON.
+func apiJsonValue(v interface{}) types.String {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return types.StringNull()
+	}
+
+	return types.StringValue(string(b))
+}
+
+// canonicalParameters returns a copy of the parameters with the top-level
+// parameters and map entries sorted by key. List items keep their order.
+func canonicalParameters(parameter []*tagmanager.Parameter) []*tagmanager.Parameter {
+	sorted := canonicalParameterList(parameter)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Key < sorted[j].Key })
+
+	return sorted
+}
+
+func canonicalParameterList(parameter []*tagmanager.Parameter) []*tagmanager.Parameter {
+	if parameter == nil {
+		return nil
+	}
+
+	copied := make([]*tagmanager.Parameter, len(parameter))
+	for i, p := range parameter {
+		c := *p
+		c.List = canonicalParameterList(p.List)
+		c.Map = canonicalParameters(p.Map)
+		copied[i] = &c
+	}
+
+	return copied
+}
+
+// planApiJson sets api_json in the plan to the JSON that render returns for
+// the planned resource, which is read into plan. api_json stays unknown while
+// other planned values are unknown.
+func planApiJson(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, plan interface{}, render func() types.String) {
+	if req.Plan.Raw.IsNull() || !planInputsKnown(req.Plan) {
+		return
+	}
+
+	diags := req.Plan.Get(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.Plan.SetAttribute(ctx, path.Root("api_json"), render())
+	resp.Diagnostics.Append(diags...)
+}
+
+// planInputsKnown returns whether all planned values are known, apart from
+// the computed attributes that are not sent to the API.
+func planInputsKnown(plan tfsdk.Plan) bool {
+	known := true
+
+	_ = tftypes.Walk(plan.Raw, func(p *tftypes.AttributePath, v tftypes.Value) (bool, error) {
+		if steps := p.Steps(); len(steps) > 0 {
+			switch steps[0] {
+			case tftypes.AttributeName("account_id"), tftypes.AttributeName("container_id"), tftypes.AttributeName("workspace_id"),
+				tftypes.AttributeName("id"), tftypes.AttributeName("api_json"):
+				return false, nil
+			}
+		}
+
+		known = known && v.IsKnown()
+		return known, nil
+	})
+
+	return known
+}
+
+// templateParameter returns a template parameter with the given key and value.
+func templateParameter(key string, value types.String) ResourceParameterModel {
+	return ResourceParameterModel{
+		Key:   types.StringValue(key),
+		Type:  types.StringValue("template"),
+		Value: value,
+	}
+}
+
+// booleanParameter returns a boolean parameter with the given key and value.
+func booleanParameter(key string, value bool) ResourceParameterModel {
+	return ResourceParameterModel{
+		Key:   types.StringValue(key),
+		Type:  types.StringValue("boolean"),
+		Value: types.StringValue(strconv.FormatBool(value)),
+	}
+}
+
+// tableParameter encodes a map as a parameter table, i.e. a list of maps with
+// a key column and a value column. Rows are sorted by key.
+func tableParameter(key, keyColumn, valueColumn string, table map[string]types.String) ResourceParameterModel {
+	keys := make([]string, 0, len(table))
+	for k := range table {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	rows := make([]ResourceParameterModel, len(keys))
+	for i, k := range keys {
+		rows[i] = ResourceParameterModel{
+			Type: types.StringValue("map"),
+			Map: []ResourceParameterModel{
+				templateParameter(keyColumn, types.StringValue(k)),
+				templateParameter(valueColumn, table[k]),
+			},
+		}
+	}
+
+	return ResourceParameterModel{
+		Key:  types.StringValue(key),
+		Type: types.StringValue("list"),
+		List: rows,
+	}
+}
+
+// findParameter returns the parameter with the given key, or nil.
+func findParameter(parameter []*tagmanager.Parameter, key string) *tagmanager.Parameter {
+	for _, p := range parameter {
+		if p.Key == key {
+			return p
+		}
+	}
+
+	return nil
+}
+
+// parameterValue returns the value of the parameter with the given key, or
+// null.
+func parameterValue(parameter []*tagmanager.Parameter, key string) types.String {
+	if p := findParameter(parameter, key); p != nil {
+		return nullableStringValue(p.Value)
+	}
+
+	return types.StringNull()
+}
+
+// parameterTable decodes the parameter table with the given key into a map,
+// or nil if there is no such table.
+func parameterTable(parameter []*tagmanager.Parameter, key, keyColumn, valueColumn string) map[string]types.String {
+	p := findParameter(parameter, key)
+	if p == nil {
+		return nil
+	}
+
+	table := make(map[string]types.String, len(p.List))
+	for _, row := range p.List {
+		k := findParameter(row.Map, keyColumn)
+		if k == nil {
+			continue
+		}
+
+		table[k.Value] = types.StringValue("")
+		if v := findParameter(row.Map, valueColumn); v != nil {
+			table[k.Value] = types.StringValue(v.Value)
+		}
+	}
+
+	return table
+}
+
+// priorParameterTable is like parameterTable, but keeps a prior empty table
+// rather than replacing it with nil.
+func priorParameterTable(parameter []*tagmanager.Parameter, key, keyColumn, valueColumn string, prior map[string]types.String) map[string]types.String {
+	table := parameterTable(parameter, key, keyColumn, valueColumn)
+	if len(table) == 0 && prior != nil && len(prior) == 0 {
+		return prior
+	}
+
+	return table
+}
+
 func nullableStringValue(s string) types.String {
 	if s != "" {
 		return types.StringValue(s)
@@ -145,6 +812,34 @@ func nullableStringValue(s string) types.String {
 	}
 }
 
+// priorStringValue is like nullableStringValue, but keeps a prior empty
+// string rather than replacing it with null.
+func priorStringValue(s string, prior types.String) types.String {
+	if s == "" && !prior.IsNull() && !prior.IsUnknown() && prior.ValueString() == "" {
+		return prior
+	}
+
+	return nullableStringValue(s)
+}
+
+func nullableInt64Value(i int64) types.Int64 {
+	if i != 0 {
+		return types.Int64Value(i)
+	} else {
+		return types.Int64Null()
+	}
+}
+
+// priorInt64Value is like nullableInt64Value, but keeps a prior 0 rather than
+// replacing it with null, since the API leaves out zeros.
+func priorInt64Value(i int64, prior types.Int64) types.Int64 {
+	if i == 0 && !prior.IsNull() && !prior.IsUnknown() && prior.ValueInt64() == 0 {
+		return prior
+	}
+
+	return nullableInt64Value(i)
+}
+
 type resourceConditionModel struct {
 	Type      types.String             `tfsdk:"type"`
 	Parameter []ResourceParameterModel `tfsdk:"parameter"`
@@ -152,13 +847,17 @@ type resourceConditionModel struct {
 
 // Equal compares two resource conditions.
 func (m resourceConditionModel) Equal(o resourceConditionModel) bool {
-	if !m.Type.Equal(o.Type) ||
-		len(m.Parameter) != len(o.Parameter) {
+	return m.Type.Equal(o.Type) && parameterSetsEqual(m.Parameter, o.Parameter)
+}
+
+// conditionsEqual compares two condition lists in order.
+func conditionsEqual(a, b []resourceConditionModel) bool {
+	if len(a) != len(b) {
 		return false
 	}
 
-	for i, p := range m.Parameter {
-		if !p.Equal(o.Parameter[i]) {
+	for i := range a {
+		if !a[i].Equal(b[i]) {
 			return false
 		}
 	}
@@ -211,6 +910,16 @@ func toResourceStringArray(list []string) []types.String {
 	return rv
 }
 
+// priorStringArray is like toResourceStringArray, but keeps a prior empty
+// list rather than replacing it with null.
+func priorStringArray(list []string, prior []types.String) []types.String {
+	if len(list) == 0 && prior != nil && len(prior) == 0 {
+		return prior
+	}
+
+	return toResourceStringArray(list)
+}
+
 func unwrapStringArray(list []types.String) []string {
 	var rv []string
 
@@ -0,0 +1,151 @@
+package provider
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/tagmanager/v2"
+)
+
+// jsonencode re-encodes JSON as Terraform's jsonencode does, i.e. compact
+// and with the object keys sorted.
+func jsonencode(t *testing.T, s string) types.String {
+	var v interface{}
+	require.NoError(t, json.Unmarshal([]byte(s), &v))
+	b, err := json.Marshal(v)
+	require.NoError(t, err)
+
+	return types.StringValue(string(b))
+}
+
+func TestParameterJsonRoundTripsDeepParameters(t *testing.T) {
+	var tag tagmanager.Tag
+	loadApiResponse(t, "tag_cvt_nested.json", &tag)
+	require.Equal(t, 5, parameterDepth(tag.Parameter))
+
+	configured := jsonencode(t, encodeParameterJson(tag.Parameter))
+	assert.Equal(t, encodeParameterJson(tag.Parameter), encodeParameterJson(toApiParameters(nil, configured, types.StringNull())))
+
+	// The API response keeps the configured JSON as written.
+	parameter, parameterJson, parameters := toResourceParameters(tag.Parameter, nil, nil, configured, types.StringNull())
+	assert.Nil(t, parameter)
+	assert.Equal(t, configured, parameterJson)
+	assert.True(t, parameters.IsNull())
+
+	// A deep value changed outside of Terraform is detected.
+	tag.Parameter[1].List[0].Map[1].List[1].Map[1].Value = "denied"
+	_, parameterJson, _ = toResourceParameters(tag.Parameter, nil, nil, configured, types.StringNull())
+	assert.NotEqual(t, configured, parameterJson)
+	decoded, err := decodeParameterJson(parameterJson.ValueString())
+	require.NoError(t, err)
+	assert.Equal(t, "denied", decoded[1].List[0].Map[1].List[1].Map[1].Value)
+}
+
+func TestToResourceParametersWithoutPriorValue(t *testing.T) {
+	// Parameters too deep for the parameter attribute are imported into
+	// parameter_json.
+	var deep tagmanager.Tag
+	loadApiResponse(t, "tag_cvt_nested.json", &deep)
+
+	parameter, parameterJson, parameters := toResourceParameters(deep.Parameter, nil, nil, types.StringNull(), types.StringNull())
+	assert.Nil(t, parameter)
+	assert.True(t, parameters.IsNull())
+	decoded, err := decodeParameterJson(parameterJson.ValueString())
+	require.NoError(t, err)
+	assert.Equal(t, encodeParameterJson(deep.Parameter), encodeParameterJson(decoded))
+
+	// Other parameters are imported into the parameter attribute.
+	var shallow tagmanager.Tag
+	loadApiResponse(t, "tag_gaawe.json", &shallow)
+
+	parameter, parameterJson, parameters = toResourceParameters(shallow.Parameter, nil, nil, types.StringNull(), types.StringNull())
+	assert.Len(t, parameter, 5)
+	assert.True(t, parameterJson.IsNull())
+	assert.True(t, parameters.IsNull())
+}
+
+func TestDecodeParametersObject(t *testing.T) {
+	parameter, err := decodeParametersObject(`{
+		"eventName": "purchase",
+		"sendEcommerceData": false,
+		"timeout": 2000,
+		"skipped": null,
+		"domains": ["example.com", "example.org"],
+		"eventParameters": [{"name": "currency", "value": "EUR"}]
+	}`)
+	require.NoError(t, err)
+
+	expected := []*tagmanager.Parameter{
+		{Key: "domains", Type: "list", List: []*tagmanager.Parameter{
+			{Type: "template", Value: "example.com"},
+			{Type: "template", Value: "example.org"},
+		}},
+		{Key: "eventName", Type: "template", Value: "purchase"},
+		{Key: "eventParameters", Type: "list", List: []*tagmanager.Parameter{
+			{Type: "map", Map: []*tagmanager.Parameter{
+				{Key: "name", Type: "template", Value: "currency"},
+				{Key: "value", Type: "template", Value: "EUR"},
+			}},
+		}},
+		{Key: "sendEcommerceData", Type: "boolean", Value: "false"},
+		{Key: "timeout", Type: "integer", Value: "2000"},
+	}
+	assert.Equal(t, expected, parameter)
+}
+
+func TestDecodeParametersObjectErrors(t *testing.T) {
+	for _, s := range []string{
+		`["not", "an", "object"]`,
+		`null`,
+		`{"ratio": 1.5}`,
+		`{"eventName": "purchase"`,
+	} {
+		_, err := decodeParametersObject(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestEncodeParametersObjectRoundTrip(t *testing.T) {
+	s := `{"domains":["example.com"],"eventName":"purchase","eventParameters":[{"name":"currency","value":"EUR"}],"sendEcommerceData":false,"timeout":2000}`
+
+	parameter, err := decodeParametersObject(s)
+	require.NoError(t, err)
+	encoded, ok := encodeParametersObject(parameter)
+	assert.True(t, ok)
+	assert.Equal(t, s, encoded)
+
+	// Types and keys that a JSON object cannot express are rejected.
+	_, ok = encodeParametersObject([]*tagmanager.Parameter{{Key: "tag", Type: "tagReference", Value: "test-html"}})
+	assert.False(t, ok)
+	_, ok = encodeParametersObject([]*tagmanager.Parameter{{Type: "template", Value: "no key"}})
+	assert.False(t, ok)
+	_, ok = encodeParametersObject([]*tagmanager.Parameter{{Key: "flag", Type: "boolean", Value: "maybe"}})
+	assert.False(t, ok)
+}
+
+func TestToResourceParametersNormalizesParametersObject(t *testing.T) {
+	var tag tagmanager.Tag
+	loadApiResponse(t, "tag_gaawe.json", &tag)
+
+	// The configured object is kept as written, whatever its formatting.
+	configured := types.StringValue(`{ "measurementIdOverride": "G-XXXXXXXX", "eventName": "purchase",
+		"eventParameters": [{ "value": "EUR", "name": "currency" }], "sendEcommerceData": false, "enhancedUserId": false }`)
+	parameter, parameterJson, parameters := toResourceParameters(tag.Parameter, serverDefaultTagParameters["gaawe"], nil, types.StringNull(), configured)
+	assert.Nil(t, parameter)
+	assert.True(t, parameterJson.IsNull())
+	assert.Equal(t, configured, parameters)
+
+	// A value changed outside of Terraform is read back normalized.
+	findParameter(tag.Parameter, "eventName").Value = "refund"
+	_, _, parameters = toResourceParameters(tag.Parameter, serverDefaultTagParameters["gaawe"], nil, types.StringNull(), configured)
+	assert.Equal(t, `{"enhancedUserId":false,"eventName":"refund","eventParameters":[{"name":"currency","value":"EUR"}],"measurementIdOverride":"G-XXXXXXXX","sendEcommerceData":false}`, parameters.ValueString())
+
+	// Parameters that a JSON object cannot express fall back to parameter.
+	tag.Parameter = append(tag.Parameter, &tagmanager.Parameter{Key: "setupTag", Type: "tagReference", Value: "test-html"})
+	parameter, _, parameters = toResourceParameters(tag.Parameter, serverDefaultTagParameters["gaawe"], nil, types.StringNull(), configured)
+	assert.NotNil(t, findParameter(toApiParameter(parameter), "setupTag"))
+	assert.True(t, parameters.IsNull())
+}
@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+)
+
+// parameterSchemaVersion is the schema version of the resources with nested
+// parameters. Each change to the shape of the parameters bumps it and adds a
+// state upgrader from the prior version.
+//
+// Version 1 made top-level parameters and map entries sets, they were lists
+// in version 0.
+const parameterSchemaVersion = 1
+
+// parameterListAttributes returns the attributes of version 0 of a schema,
+// i.e. with the parameter sets turned back into lists.
+func parameterListAttributes(attributes map[string]schema.Attribute) map[string]schema.Attribute {
+	v0 := make(map[string]schema.Attribute, len(attributes))
+	for name, attribute := range attributes {
+		v0[name] = parameterListAttribute(attribute)
+	}
+
+	return v0
+}
+
+func parameterListAttribute(attribute schema.Attribute) schema.Attribute {
+	switch a := attribute.(type) {
+	case schema.SetNestedAttribute:
+		object := schema.NestedAttributeObject{Attributes: parameterListAttributes(a.NestedObject.Attributes)}
+
+		// Parameter sets are the only sets of objects with a map attribute.
+		if _, ok := a.NestedObject.Attributes["map"]; ok {
+			return schema.ListNestedAttribute{Optional: true, NestedObject: object}
+		}
+
+		a.NestedObject = object
+		return a
+	case schema.ListNestedAttribute:
+		a.NestedObject = schema.NestedAttributeObject{Attributes: parameterListAttributes(a.NestedObject.Attributes)}
+		return a
+	case schema.SingleNestedAttribute:
+		a.Attributes = parameterListAttributes(a.Attributes)
+		return a
+	}
+
+	return attribute
+}
+
+// parameterStateUpgrader upgrades the state of a resource with the given
+// attributes from version 0. The state is read into a new model, which does
+// not tell lists and sets apart, and written back with the current schema.
+func parameterStateUpgrader(attributes map[string]schema.Attribute, newModel func() interface{}) resource.StateUpgrader {
+	return resource.StateUpgrader{
+		PriorSchema: &schema.Schema{Attributes: parameterListAttributes(attributes)},
+		StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+			state := newModel()
+			diags := req.State.Get(ctx, state)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+
+			diags = resp.State.Set(ctx, state)
+			resp.Diagnostics.Append(diags...)
+		},
+	}
+}
@@ -0,0 +1,131 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// upgradeResourceState upgrades a state of the given version to the current
+// schema and returns its attributes.
+func upgradeResourceState(t *testing.T, typeName string, version int64, state string) map[string]tftypes.Value {
+	ctx := context.Background()
+
+	server, err := providerserver.NewProtocol6WithError(New())()
+	require.NoError(t, err)
+
+	schemas, err := server.GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	require.NoError(t, err)
+	objectType := schemas.ResourceSchemas[typeName].ValueType()
+
+	resp, err := server.UpgradeResourceState(ctx, &tfprotov6.UpgradeResourceStateRequest{
+		TypeName: typeName,
+		Version:  version,
+		RawState: &tfprotov6.RawState{JSON: []byte(state)},
+	})
+	require.NoError(t, err)
+	for _, d := range resp.Diagnostics {
+		require.NotEqual(t, tfprotov6.DiagnosticSeverityError, d.Severity, "%s: %s", d.Summary, d.Detail)
+	}
+
+	upgraded, err := resp.UpgradedState.Unmarshal(objectType)
+	require.NoError(t, err)
+
+	var attributes map[string]tftypes.Value
+	require.NoError(t, upgraded.As(&attributes))
+
+	return attributes
+}
+
+func TestParameterListAttributes(t *testing.T) {
+	v0 := parameterListAttributes(tagResourceSchemaAttributes)
+
+	parameter, ok := v0["parameter"].(schema.ListNestedAttribute)
+	require.True(t, ok, "parameter is %T", v0["parameter"])
+	_, ok = parameter.NestedObject.Attributes["map"].(schema.ListNestedAttribute)
+	assert.True(t, ok, "map is %T", parameter.NestedObject.Attributes["map"])
+	_, ok = parameter.NestedObject.Attributes["list"].(schema.ListNestedAttribute)
+	assert.True(t, ok)
+
+	// The current schema is left alone.
+	_, ok = tagResourceSchemaAttributes["parameter"].(schema.SetNestedAttribute)
+	assert.True(t, ok)
+
+	// Other sets stay sets.
+	v0 = parameterListAttributes(triggerResourceSchemaAttributes)
+	_, ok = v0["simple_filter"].(schema.SetNestedAttribute)
+	assert.True(t, ok)
+	filter := v0["filter"].(schema.ListNestedAttribute)
+	_, ok = filter.NestedObject.Attributes["parameter"].(schema.ListNestedAttribute)
+	assert.True(t, ok)
+}
+
+func TestUpgradeTagStateFromVersion0(t *testing.T) {
+	// A state written before parameter_json, parameters and the sets existed.
+	state := upgradeResourceState(t, "gtm_tag", 0, `{
+		"account_id": "6105084028",
+		"container_id": "119458552",
+		"workspace_id": "12",
+		"id": "8",
+		"name": "test-purchase",
+		"type": "gaawe",
+		"notes": null,
+		"firing_trigger_id": ["2147479553"],
+		"parameter": [
+			{"key": "eventName", "type": "template", "value": "purchase", "list": null, "map": null},
+			{"key": "eventParameters", "type": "list", "value": null, "map": null, "list": [
+				{"key": null, "type": "map", "value": null, "list": null, "map": [
+					{"key": "name", "type": "template", "value": "currency", "list": null, "map": null},
+					{"key": "value", "type": "template", "value": "EUR", "list": null, "map": null}
+				]}
+			]}
+		]
+	}`)
+
+	assert.True(t, state["id"].Equal(testString("8")))
+	assert.True(t, state["parameter_json"].IsNull())
+	assert.True(t, state["parameters"].IsNull())
+
+	parameter := state["parameter"]
+	assert.True(t, parameter.Type().Is(tftypes.Set{}), "parameter is %s", parameter.Type())
+
+	var elements []tftypes.Value
+	require.NoError(t, parameter.As(&elements))
+	assert.Len(t, elements, 2)
+}
+
+func TestUpgradeZoneStateFromVersion0(t *testing.T) {
+	state := upgradeResourceState(t, "gtm_zone", 0, `{
+		"account_id": "6105084028",
+		"container_id": "119458552",
+		"workspace_id": "12",
+		"id": "2",
+		"name": "test-zone",
+		"boundary": {
+			"condition": [
+				{"type": "equals", "parameter": [
+					{"key": "arg0", "type": "template", "value": "{{Page Hostname}}", "list": null, "map": null},
+					{"key": "arg1", "type": "template", "value": "example.com", "list": null, "map": null}
+				]}
+			],
+			"custom_evaluation_trigger_id": null
+		}
+	}`)
+
+	var boundary map[string]tftypes.Value
+	require.NoError(t, state["boundary"].As(&boundary))
+
+	var condition []tftypes.Value
+	require.NoError(t, boundary["condition"].As(&condition))
+	require.Len(t, condition, 1)
+
+	var attributes map[string]tftypes.Value
+	require.NoError(t, condition[0].As(&attributes))
+	assert.True(t, attributes["parameter"].Type().Is(tftypes.Set{}))
+}
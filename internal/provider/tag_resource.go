@@ -6,12 +6,16 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"google.golang.org/api/tagmanager/v2"
 )
 
 var (
-	_ resource.ResourceWithConfigure = &tagResource{}
+	_ resource.ResourceWithConfigure      = &tagResource{}
+	_ resource.ResourceWithValidateConfig = &tagResource{}
+	_ resource.ResourceWithModifyPlan     = &tagResource{}
+	_ resource.ResourceWithUpgradeState   = &tagResource{}
 )
 
 func NewTagResource() resource.Resource {
@@ -37,19 +41,30 @@ func (r *tagResource) Metadata(_ context.Context, req resource.MetadataRequest,
 }
 
 var tagResourceSchemaAttributes = map[string]schema.Attribute{
+	"account_id":   accountIdSchema,
+	"container_id": containerIdSchema,
+	"workspace_id": workspaceIdSchema,
 	"name": schema.StringAttribute{
 		Description: "The name of the tag.",
 		Required:    true},
 	"type": schema.StringAttribute{
-		Description: "The type of the tag.",
-		Required:    true},
+		Description:   "The type of the tag, a built-in type or the type of a custom template (cvt_...). Changing it recreates the tag.",
+		Required:      true,
+		PlanModifiers: immutablePlanModifiers,
+		Validators:    []validator.String{builtInTypeValidator{"tag", builtInTagTypes}},
+	},
+	"allow_unknown_type": allowUnknownTypeSchema,
 	"id": schema.StringAttribute{
-		Description: "The ID of the tag.",
-		Computed:    true},
+		Description:   "The ID of the tag.",
+		Computed:      true,
+		PlanModifiers: idPlanModifiers},
 	"notes": schema.StringAttribute{
 		Description: "The notes associated with the tag.",
 		Optional:    true},
-	"parameter": parameterSchema,
+	"parameter":      parameterSchema,
+	"parameter_json": parameterJsonSchema,
+	"parameters":     parametersSchema,
+	"api_json":       apiJsonSchema,
 	"firing_trigger_id": schema.ListAttribute{
 		Description: "The ID of the firing triggers associated with the tag.",
 		Optional:    true,
@@ -59,33 +74,66 @@ var tagResourceSchemaAttributes = map[string]schema.Attribute{
 
 // Schema defines the schema for the resource.
 func (r *tagResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
-	resp.Schema = schema.Schema{Attributes: tagResourceSchemaAttributes}
+	resp.Schema = schema.Schema{
+		Version:    parameterSchemaVersion,
+		Attributes: tagResourceSchemaAttributes,
+	}
+}
+
+// UpgradeState upgrades the state from prior schema versions.
+func (r *tagResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: parameterStateUpgrader(tagResourceSchemaAttributes, func() interface{} { return &resourceTagModel{} }),
+	}
+}
+
+// ValidateConfig checks the parameters of the tag.
+func (r *tagResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(validateConfigParameters(ctx, req.Config)...)
+}
+
+// ModifyPlan checks the variable references of the tag against the
+// workspace and renders api_json.
+func (r *tagResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resp.Diagnostics.Append(checkPlanVariableReferences(ctx, r.client, req)...)
+
+	var plan resourceTagModel
+	planApiJson(ctx, req, resp, &plan, func() types.String { return tagApiJson(plan) })
 }
 
 type resourceTagModel struct {
-	Name            types.String             `tfsdk:"name"`
-	Type            types.String             `tfsdk:"type"`
-	Id              types.String             `tfsdk:"id"`
-	Notes           types.String             `tfsdk:"notes"`
-	Parameter       []ResourceParameterModel `tfsdk:"parameter"`
-	FiringTriggerId []types.String           `tfsdk:"firing_trigger_id"`
+	AccountId        types.String             `tfsdk:"account_id"`
+	ContainerId      types.String             `tfsdk:"container_id"`
+	WorkspaceId      types.String             `tfsdk:"workspace_id"`
+	Name             types.String             `tfsdk:"name"`
+	Type             types.String             `tfsdk:"type"`
+	Id               types.String             `tfsdk:"id"`
+	Notes            types.String             `tfsdk:"notes"`
+	Parameter        []ResourceParameterModel `tfsdk:"parameter"`
+	ParameterJson    types.String             `tfsdk:"parameter_json"`
+	Parameters       types.String             `tfsdk:"parameters"`
+	FiringTriggerId  []types.String           `tfsdk:"firing_trigger_id"`
+	ApiJson          types.String             `tfsdk:"api_json"`
+	AllowUnknownType types.Bool               `tfsdk:"allow_unknown_type"`
 }
 
 // Equal compares the two models and returns true if they are equal.
 func (m resourceTagModel) Equal(o resourceTagModel) bool {
-	if !m.Name.Equal(o.Name) ||
+	if (!m.AccountId.IsUnknown() && !m.AccountId.Equal(o.AccountId)) ||
+		(!m.ContainerId.IsUnknown() && !m.ContainerId.Equal(o.ContainerId)) ||
+		(!m.WorkspaceId.IsUnknown() && !m.WorkspaceId.Equal(o.WorkspaceId)) ||
+		!m.Name.Equal(o.Name) ||
 		!m.Type.Equal(o.Type) ||
 		(!m.Id.IsUnknown() && !m.Id.Equal(o.Id)) ||
 		!m.Notes.Equal(o.Notes) ||
-		len(m.Parameter) != len(o.Parameter) ||
+		!m.ParameterJson.Equal(o.ParameterJson) ||
+		!m.Parameters.Equal(o.Parameters) ||
 		len(m.FiringTriggerId) != len(o.FiringTriggerId) {
 		return false
 	}
 
-	for i := range m.Parameter {
-		if !m.Parameter[i].Equal(o.Parameter[i]) {
-			return false
-		}
+	if !parameterSetsEqual(m.Parameter, o.Parameter) {
+		return false
 	}
 
 	for i := range m.FiringTriggerId {
@@ -97,25 +145,48 @@ func (m resourceTagModel) Equal(o resourceTagModel) bool {
 	return true
 }
 
-func toResourceTag(tag *tagmanager.Tag) resourceTagModel {
-	return resourceTagModel{
-		Name:            types.StringValue(tag.Name),
-		Type:            types.StringValue(tag.Type),
-		Id:              types.StringValue(tag.TagId),
-		Notes:           nullableStringValue(tag.Notes),
-		Parameter:       toResourceParameter(tag.Parameter),
-		FiringTriggerId: toResourceStringArray(tag.FiringTriggerId),
+func toResourceTag(tag *tagmanager.Tag, prior resourceTagModel) resourceTagModel {
+	parameter, parameterJson, parameters := toResourceParameters(tag.Parameter, serverDefaultTagParameters[tag.Type], prior.Parameter, prior.ParameterJson, prior.Parameters)
+
+	state := resourceTagModel{
+		AccountId:        types.StringValue(tag.AccountId),
+		ContainerId:      types.StringValue(tag.ContainerId),
+		WorkspaceId:      types.StringValue(tag.WorkspaceId),
+		Name:             types.StringValue(tag.Name),
+		Type:             types.StringValue(tag.Type),
+		Id:               types.StringValue(tag.TagId),
+		Notes:            priorStringValue(tag.Notes, prior.Notes),
+		Parameter:        parameter,
+		ParameterJson:    parameterJson,
+		Parameters:       parameters,
+		FiringTriggerId:  priorStringArray(tag.FiringTriggerId, prior.FiringTriggerId),
+		ApiJson:          prior.ApiJson,
+		AllowUnknownType: prior.AllowUnknownType,
 	}
 
+	if state.ApiJson.IsNull() || state.ApiJson.IsUnknown() || !prior.Equal(state) {
+		state.ApiJson = tagApiJson(state)
+	}
+
+	return state
+}
+
+// tagApiJson renders the tag that toApiTag sends for the resource.
+func tagApiJson(resource resourceTagModel) types.String {
+	tag := toApiTag(resource)
+	tag.TagId = ""
+	tag.Parameter = canonicalParameters(tag.Parameter)
+
+	return apiJsonValue(tag)
 }
 
 func toApiTag(resource resourceTagModel) *tagmanager.Tag {
 	return &tagmanager.Tag{
 		Name:            resource.Name.ValueString(),
 		Type:            resource.Type.ValueString(),
-		TagId:           resource.Id.String(),
+		TagId:           resource.Id.ValueString(),
 		Notes:           resource.Notes.ValueString(),
-		Parameter:       toApiParameter(resource.Parameter),
+		Parameter:       toApiParameters(resource.Parameter, resource.ParameterJson, resource.Parameters),
 		FiringTriggerId: unwrapStringArray(resource.FiringTriggerId),
 	}
 }
@@ -129,13 +200,19 @@ func (r *tagResource) Create(ctx context.Context, req resource.CreateRequest, re
 		return
 	}
 
-	tag, err := r.client.CreateTag(toApiTag(plan))
+	client, err := clientInWorkspace(ctx, r.client, plan.AccountId, plan.ContainerId, plan.WorkspaceId)
 	if err != nil {
 		resp.Diagnostics.AddError("Error Creating Tag", err.Error())
 		return
 	}
 
-	diags = resp.State.Set(ctx, toResourceTag(tag))
+	tag, err := client.CreateTag(toApiTag(plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Creating Tag", err.Error())
+		return
+	}
+
+	diags = resp.State.Set(ctx, toResourceTag(tag, plan))
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -151,7 +228,13 @@ func (r *tagResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 		return
 	}
 
-	tag, err := r.client.Tag(state.Id.ValueString())
+	client, err := clientInWorkspace(ctx, r.client, state.AccountId, state.ContainerId, state.WorkspaceId)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Tag", err.Error())
+		return
+	}
+
+	tag, err := client.Tag(state.Id.ValueString())
 	if err == api.ErrNotExist {
 		resp.State.RemoveResource(ctx)
 		return
@@ -160,7 +243,7 @@ func (r *tagResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 		return
 	}
 
-	diags = resp.State.Set(ctx, toResourceTag(tag))
+	diags = resp.State.Set(ctx, toResourceTag(tag, state))
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -181,13 +264,19 @@ func (r *tagResource) Update(ctx context.Context, req resource.UpdateRequest, re
 		return
 	}
 
-	tag, err := r.client.UpdateTag(state.Id.ValueString(), toApiTag(plan))
+	client, err := clientInWorkspace(ctx, r.client, state.AccountId, state.ContainerId, state.WorkspaceId)
 	if err != nil {
 		resp.Diagnostics.AddError("Error Updating Tag", err.Error())
 		return
 	}
 
-	diags = resp.State.Set(ctx, toResourceTag(tag))
+	tag, err := client.UpdateTag(state.Id.ValueString(), toApiTag(plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Updating Tag", err.Error())
+		return
+	}
+
+	diags = resp.State.Set(ctx, toResourceTag(tag, plan))
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -204,7 +293,13 @@ func (r *tagResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 		return
 	}
 
-	err := r.client.DeleteTag(state.Id.ValueString())
+	client, err := clientInWorkspace(ctx, r.client, state.AccountId, state.ContainerId, state.WorkspaceId)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Deleting Tag", err.Error())
+		return
+	}
+
+	err = client.DeleteTag(state.Id.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error Deleting Tag", err.Error())
 		return
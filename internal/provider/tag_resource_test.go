@@ -0,0 +1,175 @@
+package provider
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/tagmanager/v2"
+)
+
+// loadApiResponse decodes an API response from the testdata directory.
+func loadApiResponse(t *testing.T, name string, v interface{}) {
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, v))
+}
+
+func testHtmlTagPlan() resourceTagModel {
+	return resourceTagModel{
+		AccountId:     types.StringValue("6105084028"),
+		ContainerId:   types.StringValue("119458552"),
+		WorkspaceId:   types.StringValue("12"),
+		Name:          types.StringValue("test-html"),
+		Type:          types.StringValue("html"),
+		Id:            types.StringUnknown(),
+		Notes:         types.StringNull(),
+		Parameter:     []ResourceParameterModel{templateParameter("html", types.StringValue("<p>Hello</p>"))},
+		ParameterJson: types.StringNull(),
+		Parameters:    types.StringNull(),
+		FiringTriggerId: []types.String{
+			types.StringValue("2147479553"),
+		},
+	}
+}
+
+func TestToResourceTagIgnoresServerDefaults(t *testing.T) {
+	var tag tagmanager.Tag
+	loadApiResponse(t, "tag_html.json", &tag)
+
+	plan := testHtmlTagPlan()
+	state := toResourceTag(&tag, plan)
+
+	assert.True(t, plan.Equal(state), "state %+v differs from plan", state)
+	assert.Equal(t, types.StringValue("7"), state.Id)
+
+	// A refresh keeps the state as it is.
+	assert.True(t, state.Equal(toResourceTag(&tag, state)))
+}
+
+func TestToResourceTagKeepsConfiguredDefaults(t *testing.T) {
+	var tag tagmanager.Tag
+	loadApiResponse(t, "tag_html.json", &tag)
+
+	plan := testHtmlTagPlan()
+	plan.Parameter = append(plan.Parameter, booleanParameter("supportDocumentWrite", false))
+	state := toResourceTag(&tag, plan)
+
+	assert.True(t, plan.Equal(state), "state %+v differs from plan", state)
+}
+
+func TestToResourceTagKeepsEmptyValues(t *testing.T) {
+	var tag tagmanager.Tag
+	loadApiResponse(t, "tag_html.json", &tag)
+	tag.FiringTriggerId = nil
+
+	plan := testHtmlTagPlan()
+	plan.Notes = types.StringValue("")
+	plan.FiringTriggerId = []types.String{}
+	state := toResourceTag(&tag, plan)
+
+	assert.Equal(t, types.StringValue(""), state.Notes)
+	assert.NotNil(t, state.FiringTriggerId)
+	assert.Empty(t, state.FiringTriggerId)
+
+	// Without a prior value, such as on import, empty values are null.
+	imported := toResourceTag(&tag, resourceTagModel{})
+	assert.True(t, imported.Notes.IsNull())
+	assert.Nil(t, imported.FiringTriggerId)
+	assert.Len(t, imported.Parameter, 1)
+}
+
+func TestToResourceTagParametersIgnoresServerDefaults(t *testing.T) {
+	var tag tagmanager.Tag
+	loadApiResponse(t, "tag_gaawe.json", &tag)
+
+	plan := resourceTagModel{
+		Parameter:     nil,
+		ParameterJson: types.StringNull(),
+		Parameters:    types.StringValue(`{"eventName":"purchase","eventParameters":[{"name":"currency","value":"EUR"}],"measurementIdOverride":"G-XXXXXXXX"}`),
+	}
+	state := toResourceTag(&tag, plan)
+
+	assert.Equal(t, plan.Parameters, state.Parameters)
+	assert.True(t, state.ParameterJson.IsNull())
+	assert.Nil(t, state.Parameter)
+}
+
+func TestTagApiJsonIsCanonical(t *testing.T) {
+	plan := testHtmlTagPlan()
+	plan.Parameter = append([]ResourceParameterModel{booleanParameter("supportDocumentWrite", false)}, plan.Parameter...)
+
+	apiJson := tagApiJson(plan).ValueString()
+	assert.Less(t, strings.Index(apiJson, `"key": "html"`), strings.Index(apiJson, `"key": "supportDocumentWrite"`))
+	assert.NotContains(t, apiJson, `"tagId"`)
+
+	// The order of the configured parameters does not change the JSON.
+	plan.Parameter = []ResourceParameterModel{plan.Parameter[1], plan.Parameter[0]}
+	assert.Equal(t, apiJson, tagApiJson(plan).ValueString())
+}
+
+func TestToResourceTagIgnoresParameterOrder(t *testing.T) {
+	var written, reordered tagmanager.Tag
+	loadApiResponse(t, "tag_gaawe.json", &written)
+	loadApiResponse(t, "tag_gaawe_reordered.json", &reordered)
+
+	// The API returned the top-level parameters and the map entries in
+	// another order than they were written.
+	plan := toResourceTag(&written, resourceTagModel{})
+	state := toResourceTag(&reordered, plan)
+	assert.NotEqual(t, plan.Parameter[0].Key, state.Parameter[0].Key)
+	assert.True(t, parameterSetsEqual(plan.Parameter, state.Parameter))
+	assert.True(t, plan.Equal(state))
+
+	plan = toResourceTag(&written, resourceTagModel{ParameterJson: types.StringValue("[]")})
+	state = toResourceTag(&reordered, plan)
+	assert.Equal(t, plan.ParameterJson, state.ParameterJson)
+	assert.True(t, plan.Equal(state))
+
+	plan = toResourceTag(&written, resourceTagModel{Parameters: types.StringValue("{}")})
+	state = toResourceTag(&reordered, plan)
+	assert.Equal(t, plan.Parameters, state.Parameters)
+	assert.True(t, plan.Equal(state))
+}
+
+func TestParameterListOrderIsSignificant(t *testing.T) {
+	item := func(value string) ResourceParameterModel {
+		return ResourceParameterModel{Key: types.StringNull(), Type: types.StringValue("template"), Value: types.StringValue(value)}
+	}
+	list := func(items ...ResourceParameterModel) []ResourceParameterModel {
+		return []ResourceParameterModel{{Key: types.StringValue("allowlist"), Type: types.StringValue("list"), Value: types.StringNull(), List: items}}
+	}
+
+	assert.True(t, parameterSetsEqual(list(item("a"), item("b")), list(item("a"), item("b"))))
+	assert.False(t, parameterSetsEqual(list(item("a"), item("b")), list(item("b"), item("a"))))
+
+	var reordered tagmanager.Tag
+	loadApiResponse(t, "tag_gaawe_reordered.json", &reordered)
+	plan := toResourceTag(&reordered, resourceTagModel{})
+
+	// Reordering the items of a list is a change.
+	eventParameters := reordered.Parameter[2]
+	require.Equal(t, "eventParameters", eventParameters.Key)
+	eventParameters.List = append(eventParameters.List, &tagmanager.Parameter{
+		Type: "map",
+		Map: []*tagmanager.Parameter{
+			{Type: "template", Key: "name", Value: "value"},
+			{Type: "template", Key: "value", Value: "{{Order Value}}"},
+		},
+	})
+	plan = toResourceTag(&reordered, plan)
+	eventParameters.List[0], eventParameters.List[1] = eventParameters.List[1], eventParameters.List[0]
+	state := toResourceTag(&reordered, plan)
+	assert.False(t, plan.Equal(state))
+
+	eventParameters.List[0], eventParameters.List[1] = eventParameters.List[1], eventParameters.List[0]
+	plan = toResourceTag(&reordered, resourceTagModel{ParameterJson: types.StringValue("[]")})
+	eventParameters.List[0], eventParameters.List[1] = eventParameters.List[1], eventParameters.List[0]
+	state = toResourceTag(&reordered, plan)
+	assert.NotEqual(t, plan.ParameterJson, state.ParameterJson)
+}
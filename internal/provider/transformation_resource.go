@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"terraform-provider-google-tag-manager/internal/api"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/api/tagmanager/v2"
+)
+
+func NewTransformationResource() resource.Resource {
+	return &serverEntityResource{kind: transformationKind}
+}
+
+// transformationKind manages the transformations of server containers.
+var transformationKind = serverEntityKind{
+	typeName: "transformation",
+	noun:     "transformation",
+	title:    "Transformation",
+
+	create: func(client *api.ClientInWorkspace, entity *serverEntity) (*serverEntity, error) {
+		transformation, err := client.CreateTransformation(toApiTransformation(entity))
+		if err != nil {
+			return nil, err
+		}
+		return toServerEntityFromTransformation(transformation), nil
+	},
+	read: func(client *api.ClientInWorkspace, id string) (*serverEntity, error) {
+		transformation, err := client.Transformation(id)
+		if err != nil {
+			return nil, err
+		}
+		return toServerEntityFromTransformation(transformation), nil
+	},
+	update: func(client *api.ClientInWorkspace, id string, entity *serverEntity) (*serverEntity, error) {
+		transformation, err := client.UpdateTransformation(id, toApiTransformation(entity))
+		if err != nil {
+			return nil, err
+		}
+		return toServerEntityFromTransformation(transformation), nil
+	},
+	delete: func(client *api.ClientInWorkspace, id string) error {
+		return client.DeleteTransformation(id)
+	},
+}
+
+// resourceTransformationModel is the model of transformations, i.e. the
+// server entity model without priority.
+type resourceTransformationModel struct {
+	AccountId     types.String             `tfsdk:"account_id"`
+	ContainerId   types.String             `tfsdk:"container_id"`
+	WorkspaceId   types.String             `tfsdk:"workspace_id"`
+	Name          types.String             `tfsdk:"name"`
+	Type          types.String             `tfsdk:"type"`
+	Id            types.String             `tfsdk:"id"`
+	Notes         types.String             `tfsdk:"notes"`
+	Parameter     []ResourceParameterModel `tfsdk:"parameter"`
+	ParameterJson types.String             `tfsdk:"parameter_json"`
+	Parameters    types.String             `tfsdk:"parameters"`
+}
+
+func (m resourceTransformationModel) serverEntityModel() resourceServerEntityModel {
+	return resourceServerEntityModel{
+		AccountId:     m.AccountId,
+		ContainerId:   m.ContainerId,
+		WorkspaceId:   m.WorkspaceId,
+		Name:          m.Name,
+		Type:          m.Type,
+		Id:            m.Id,
+		Notes:         m.Notes,
+		Priority:      types.Int64Null(),
+		Parameter:     m.Parameter,
+		ParameterJson: m.ParameterJson,
+		Parameters:    m.Parameters,
+	}
+}
+
+func toResourceTransformationModel(m resourceServerEntityModel) resourceTransformationModel {
+	return resourceTransformationModel{
+		AccountId:     m.AccountId,
+		ContainerId:   m.ContainerId,
+		WorkspaceId:   m.WorkspaceId,
+		Name:          m.Name,
+		Type:          m.Type,
+		Id:            m.Id,
+		Notes:         m.Notes,
+		Parameter:     m.Parameter,
+		ParameterJson: m.ParameterJson,
+		Parameters:    m.Parameters,
+	}
+}
+
+func toServerEntityFromTransformation(transformation *tagmanager.Transformation) *serverEntity {
+	return &serverEntity{
+		AccountId:   transformation.AccountId,
+		ContainerId: transformation.ContainerId,
+		WorkspaceId: transformation.WorkspaceId,
+		Id:          transformation.TransformationId,
+		Name:        transformation.Name,
+		Type:        transformation.Type,
+		Notes:       transformation.Notes,
+		Parameter:   transformation.Parameter,
+	}
+}
+
+func toApiTransformation(entity *serverEntity) *tagmanager.Transformation {
+	return &tagmanager.Transformation{
+		Name:             entity.Name,
+		Type:             entity.Type,
+		TransformationId: entity.Id,
+		Notes:            entity.Notes,
+		Parameter:        entity.Parameter,
+	}
+}
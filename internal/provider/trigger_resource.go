@@ -2,16 +2,23 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"regexp"
 	"terraform-provider-google-tag-manager/internal/api"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"google.golang.org/api/tagmanager/v2"
 )
 
 var (
-	_ resource.ResourceWithConfigure = &triggerResource{}
+	_ resource.ResourceWithConfigure      = &triggerResource{}
+	_ resource.ResourceWithValidateConfig = &triggerResource{}
+	_ resource.ResourceWithModifyPlan     = &triggerResource{}
+	_ resource.ResourceWithUpgradeState   = &triggerResource{}
 )
 
 func NewTriggerResource() resource.Resource {
@@ -37,54 +44,311 @@ func (r *triggerResource) Metadata(_ context.Context, req resource.MetadataReque
 }
 
 var triggerResourceSchemaAttributes = map[string]schema.Attribute{
+	"account_id":   accountIdSchema,
+	"container_id": containerIdSchema,
+	"workspace_id": workspaceIdSchema,
 	"name": schema.StringAttribute{
 		Description: "The name of the trigger.",
 		Required:    true,
 	},
 	"type": schema.StringAttribute{
-		Description: "The type of the trigger.",
-		Required:    true,
+		Description:   "The type of the trigger, a built-in type or the type of a custom template (cvt_...). Changing it recreates the trigger.",
+		Required:      true,
+		PlanModifiers: immutablePlanModifiers,
+		Validators:    []validator.String{builtInTypeValidator{"trigger", builtInTriggerTypes}},
 	},
+	"allow_unknown_type": allowUnknownTypeSchema,
 	"id": schema.StringAttribute{
-		Description: "The ID of the trigger.",
-		Computed:    true,
+		Description:   "The ID of the trigger.",
+		Computed:      true,
+		PlanModifiers: idPlanModifiers,
 	},
 	"notes": schema.StringAttribute{
 		Description: "The notes of the trigger.",
 		Optional:    true,
 	},
+	"filter":              conditionSchema,
+	"auto_event_filter":   conditionSchema,
 	"custom_event_filter": conditionSchema,
+	"simple_filter":       simpleFilterSchema,
+	"api_json":            apiJsonSchema,
+}
+
+// simpleFilterVariables maps the variable names of simple_filter to the
+// built-in variables they refer to.
+var simpleFilterVariables = map[string]string{
+	"page_url":      "{{Page URL}}",
+	"page_hostname": "{{Page Hostname}}",
+	"page_path":     "{{Page Path}}",
+	"referrer":      "{{Referrer}}",
+	"event":         "{{_event}}",
+	"click_element": "{{Click Element}}",
+	"click_classes": "{{Click Classes}}",
+	"click_id":      "{{Click ID}}",
+	"click_target":  "{{Click Target}}",
+	"click_url":     "{{Click URL}}",
+	"click_text":    "{{Click Text}}",
+	"form_element":  "{{Form Element}}",
+	"form_classes":  "{{Form Classes}}",
+	"form_id":       "{{Form ID}}",
+	"form_target":   "{{Form Target}}",
+	"form_url":      "{{Form URL}}",
+	"form_text":     "{{Form Text}}",
+}
+
+// simpleFilterOperators maps the operators of simple_filter to condition
+// types.
+var simpleFilterOperators = map[string]string{
+	"equals":               "equals",
+	"contains":             "contains",
+	"starts_with":          "startsWith",
+	"ends_with":            "endsWith",
+	"matches_regex":        "matchRegex",
+	"matches_css_selector": "cssSelector",
+	"less":                 "less",
+	"less_or_equals":       "lessOrEquals",
+	"greater":              "greater",
+	"greater_or_equals":    "greaterOrEquals",
+}
+
+var simpleFilterVariableRegexp = regexp.MustCompile(`^\{\{[^{}]+\}\}$`)
+
+var simpleFilterSchema = schema.SetNestedAttribute{
+	Description: "Conditions written as variable, operator and value, e.g. page_path contains /checkout or event equals purchase. Conditions on event expand into custom_event_filter, all others into filter. Conflicts with filter and custom_event_filter.",
+	Optional:    true,
+	Validators:  []validator.Set{simpleFilterValidator{}},
+	NestedObject: schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"variable": schema.StringAttribute{
+				Description: "A built-in variable, one of page_url, page_hostname, page_path, referrer, event, click_element, click_classes, click_id, click_target, click_url, click_text, form_element, form_classes, form_id, form_target, form_url and form_text, or a reference to any other variable, i.e. its name in double curly braces.",
+				Required:    true,
+				Validators:  []validator.String{simpleFilterVariableValidator{}},
+			},
+			"operator": schema.StringAttribute{
+				Description: "The comparison, one of equals, contains, starts_with, ends_with, matches_regex, matches_css_selector, less, less_or_equals, greater and greater_or_equals.",
+				Required:    true,
+				Validators: []validator.String{stringOneOfValidator{[]string{
+					"equals", "contains", "starts_with", "ends_with", "matches_regex", "matches_css_selector",
+					"less", "less_or_equals", "greater", "greater_or_equals",
+				}}},
+			},
+			"value": schema.StringAttribute{
+				Description: "The value to compare the variable with.",
+				Required:    true,
+			},
+			"negate": schema.BoolAttribute{
+				Description: "Whether the condition is negated, e.g. does not contain.",
+				Optional:    true,
+			},
+			"ignore_case": schema.BoolAttribute{
+				Description: "Whether matches_regex ignores case.",
+				Optional:    true,
+			},
+		},
+	},
+}
+
+// simpleFilterVariableValidator checks that a simple_filter variable is a
+// known built-in variable or a variable reference.
+type simpleFilterVariableValidator struct{}
+
+func (v simpleFilterVariableValidator) Description(_ context.Context) string {
+	return "value must be a built-in variable name or a variable reference such as {{My Variable}}"
+}
+
+func (v simpleFilterVariableValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v simpleFilterVariableValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	variable := req.ConfigValue.ValueString()
+	if _, ok := simpleFilterVariables[variable]; ok || simpleFilterVariableRegexp.MatchString(variable) {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(req.Path, "Invalid Variable", fmt.Sprintf("%q is not valid, %s.", variable, v.Description(ctx)))
+}
+
+// simpleFilterValidator checks that simple_filter is not combined with the
+// condition lists it expands into.
+type simpleFilterValidator struct{}
+
+func (v simpleFilterValidator) Description(_ context.Context) string {
+	return "filter and custom_event_filter must not be set"
+}
+
+func (v simpleFilterValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v simpleFilterValidator) ValidateSet(ctx context.Context, req validator.SetRequest, resp *validator.SetResponse) {
+	if req.ConfigValue.IsNull() {
+		return
+	}
+
+	for _, name := range setSiblingAttributes(ctx, req.Config, req.Path, []string{"filter", "custom_event_filter"}) {
+		resp.Diagnostics.AddAttributeError(req.Path, "Conflicting Filters", fmt.Sprintf("Cannot be set together with %s.", name))
+	}
 }
 
 // Schema defines the schema for the resource.
 func (r *triggerResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
-	resp.Schema = schema.Schema{Attributes: triggerResourceSchemaAttributes}
+	resp.Schema = schema.Schema{
+		Version:    parameterSchemaVersion,
+		Attributes: triggerResourceSchemaAttributes,
+	}
+}
+
+// UpgradeState upgrades the state from prior schema versions.
+func (r *triggerResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: parameterStateUpgrader(triggerResourceSchemaAttributes, func() interface{} { return &resourceTriggerModel{} }),
+	}
+}
+
+// ValidateConfig checks the condition parameters of the trigger.
+func (r *triggerResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(validateConfigConditions(ctx, req.Config, path.Root("filter"), path.Root("auto_event_filter"), path.Root("custom_event_filter"))...)
+}
+
+// ModifyPlan checks the variable references of the trigger against the
+// workspace and renders api_json.
+func (r *triggerResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resp.Diagnostics.Append(checkPlanVariableReferences(ctx, r.client, req)...)
+
+	var plan resourceTriggerModel
+	planApiJson(ctx, req, resp, &plan, func() types.String { return triggerApiJson(plan) })
 }
 
 type resourceTriggerModel struct {
-	Name              types.String             `tfsdk:"name"`
-	Type              types.String             `tfsdk:"type"`
-	Id                types.String             `tfsdk:"id"`
-	Notes             types.String             `tfsdk:"notes"`
-	CustomEventFilter []resourceConditionModel `tfsdk:"custom_event_filter"`
+	AccountId         types.String                `tfsdk:"account_id"`
+	ContainerId       types.String                `tfsdk:"container_id"`
+	WorkspaceId       types.String                `tfsdk:"workspace_id"`
+	Name              types.String                `tfsdk:"name"`
+	Type              types.String                `tfsdk:"type"`
+	Id                types.String                `tfsdk:"id"`
+	Notes             types.String                `tfsdk:"notes"`
+	Filter            []resourceConditionModel    `tfsdk:"filter"`
+	AutoEventFilter   []resourceConditionModel    `tfsdk:"auto_event_filter"`
+	CustomEventFilter []resourceConditionModel    `tfsdk:"custom_event_filter"`
+	SimpleFilter      []resourceSimpleFilterModel `tfsdk:"simple_filter"`
+	ApiJson           types.String                `tfsdk:"api_json"`
+	AllowUnknownType  types.Bool                  `tfsdk:"allow_unknown_type"`
+}
+
+type resourceSimpleFilterModel struct {
+	Variable   types.String `tfsdk:"variable"`
+	Operator   types.String `tfsdk:"operator"`
+	Value      types.String `tfsdk:"value"`
+	Negate     types.Bool   `tfsdk:"negate"`
+	IgnoreCase types.Bool   `tfsdk:"ignore_case"`
+}
+
+// Equal compares two simple filters.
+func (m resourceSimpleFilterModel) Equal(o resourceSimpleFilterModel) bool {
+	return m.Variable.Equal(o.Variable) &&
+		m.Operator.Equal(o.Operator) &&
+		m.Value.Equal(o.Value) &&
+		m.Negate.Equal(o.Negate) &&
+		m.IgnoreCase.Equal(o.IgnoreCase)
+}
+
+// expandSimpleFilter returns the condition that the simple filter stands for
+// and whether it belongs in custom_event_filter rather than filter.
+func expandSimpleFilter(f resourceSimpleFilterModel) (resourceConditionModel, bool) {
+	variable := f.Variable.ValueString()
+	if v, ok := simpleFilterVariables[variable]; ok {
+		variable = v
+	}
+
+	parameter := []ResourceParameterModel{
+		templateParameter("arg0", types.StringValue(variable)),
+		templateParameter("arg1", f.Value),
+	}
+
+	if f.IgnoreCase.ValueBool() {
+		parameter = append(parameter, booleanParameter("ignore_case", true))
+	}
+
+	if f.Negate.ValueBool() {
+		parameter = append(parameter, booleanParameter("negate", true))
+	}
+
+	condition := resourceConditionModel{
+		Type:      types.StringValue(simpleFilterOperators[f.Operator.ValueString()]),
+		Parameter: parameter,
+	}
+
+	return condition, variable == "{{_event}}"
+}
+
+// toResourceSimpleFilter maps the conditions of filter and custom_event_filter
+// back onto the prior simple filters that expand into them. It returns false
+// unless every condition and every simple filter is matched.
+func toResourceSimpleFilter(filter, customEventFilter []resourceConditionModel, prior []resourceSimpleFilterModel) ([]resourceSimpleFilterModel, bool) {
+	if prior == nil || len(filter)+len(customEventFilter) != len(prior) {
+		return nil, false
+	}
+
+	used := make([]bool, len(prior))
+	simpleFilter := make([]resourceSimpleFilterModel, 0, len(prior))
+
+	for _, conditions := range []struct {
+		condition []resourceConditionModel
+		event     bool
+	}{{filter, false}, {customEventFilter, true}} {
+		for _, c := range conditions.condition {
+			found := false
+
+			for i, f := range prior {
+				if expanded, event := expandSimpleFilter(f); !used[i] && event == conditions.event && expanded.Equal(c) {
+					used[i] = true
+					simpleFilter = append(simpleFilter, f)
+					found = true
+					break
+				}
+			}
+
+			if !found {
+				return nil, false
+			}
+		}
+	}
+
+	return simpleFilter, true
 }
 
 // Equal compares the trigger resource model with the given resource model
 
 func (m resourceTriggerModel) Equal(o resourceTriggerModel) bool {
-	if !m.Name.Equal(o.Name) ||
+	if (!m.AccountId.IsUnknown() && !m.AccountId.Equal(o.AccountId)) ||
+		(!m.ContainerId.IsUnknown() && !m.ContainerId.Equal(o.ContainerId)) ||
+		(!m.WorkspaceId.IsUnknown() && !m.WorkspaceId.Equal(o.WorkspaceId)) ||
+		!m.Name.Equal(o.Name) ||
 		!m.Type.Equal(o.Type) ||
 		(!m.Id.IsUnknown() && !m.Id.Equal(o.Id)) ||
-		!m.Notes.Equal(o.Notes) {
+		!m.Notes.Equal(o.Notes) ||
+		!conditionsEqual(m.Filter, o.Filter) ||
+		!conditionsEqual(m.AutoEventFilter, o.AutoEventFilter) ||
+		!conditionsEqual(m.CustomEventFilter, o.CustomEventFilter) ||
+		len(m.SimpleFilter) != len(o.SimpleFilter) {
 		return false
 	}
 
-	if len(m.CustomEventFilter) != len(o.CustomEventFilter) {
-		return false
-	}
+	for _, f := range m.SimpleFilter {
+		found := false
+		for _, g := range o.SimpleFilter {
+			if f.Equal(g) {
+				found = true
+				break
+			}
+		}
 
-	for i := range m.CustomEventFilter {
-		if !m.CustomEventFilter[i].Equal(o.CustomEventFilter[i]) {
+		if !found {
 			return false
 		}
 	}
@@ -92,23 +356,79 @@ func (m resourceTriggerModel) Equal(o resourceTriggerModel) bool {
 	return true
 }
 
-func toResourceTrigger(trigger *tagmanager.Trigger) resourceTriggerModel {
-	return resourceTriggerModel{
+func toResourceTrigger(trigger *tagmanager.Trigger, prior resourceTriggerModel) resourceTriggerModel {
+	var filter, autoEventFilter, customEventFilter []resourceConditionModel
+	if trigger.Filter != nil {
+		filter = toResourceCondition(trigger.Filter)
+	}
+	if trigger.AutoEventFilter != nil {
+		autoEventFilter = toResourceCondition(trigger.AutoEventFilter)
+	}
+	if trigger.CustomEventFilter != nil {
+		customEventFilter = toResourceCondition(trigger.CustomEventFilter)
+	}
+
+	simpleFilter, ok := toResourceSimpleFilter(filter, customEventFilter, prior.SimpleFilter)
+	if ok {
+		filter, customEventFilter = nil, nil
+	}
+
+	state := resourceTriggerModel{
+		AccountId:         types.StringValue(trigger.AccountId),
+		ContainerId:       types.StringValue(trigger.ContainerId),
+		WorkspaceId:       types.StringValue(trigger.WorkspaceId),
 		Name:              types.StringValue(trigger.Name),
 		Type:              types.StringValue(trigger.Type),
 		Id:                types.StringValue(trigger.TriggerId),
-		Notes:             nullableStringValue(trigger.Notes),
-		CustomEventFilter: toResourceCondition(trigger.CustomEventFilter),
+		Notes:             priorStringValue(trigger.Notes, prior.Notes),
+		Filter:            filter,
+		AutoEventFilter:   autoEventFilter,
+		CustomEventFilter: customEventFilter,
+		SimpleFilter:      simpleFilter,
+		ApiJson:           prior.ApiJson,
+		AllowUnknownType:  prior.AllowUnknownType,
+	}
+
+	if state.ApiJson.IsNull() || state.ApiJson.IsUnknown() || !prior.Equal(state) {
+		state.ApiJson = triggerApiJson(state)
+	}
+
+	return state
+}
+
+// triggerApiJson renders the trigger that toApiTrigger sends for the
+// resource.
+func triggerApiJson(resource resourceTriggerModel) types.String {
+	trigger := toApiTrigger(resource)
+	trigger.TriggerId = ""
+	for _, conditions := range [][]*tagmanager.Condition{trigger.Filter, trigger.AutoEventFilter, trigger.CustomEventFilter} {
+		for _, condition := range conditions {
+			condition.Parameter = canonicalParameters(condition.Parameter)
+		}
 	}
+
+	return apiJsonValue(trigger)
 }
 
 func toApiTrigger(resource resourceTriggerModel) *tagmanager.Trigger {
+	filter := resource.Filter
+	customEventFilter := resource.CustomEventFilter
+	for _, f := range resource.SimpleFilter {
+		if condition, event := expandSimpleFilter(f); event {
+			customEventFilter = append(customEventFilter, condition)
+		} else {
+			filter = append(filter, condition)
+		}
+	}
+
 	return &tagmanager.Trigger{
 		Name:              resource.Name.ValueString(),
 		Type:              resource.Type.ValueString(),
 		TriggerId:         resource.Id.ValueString(),
 		Notes:             resource.Notes.ValueString(),
-		CustomEventFilter: toApiCondition(resource.CustomEventFilter),
+		Filter:            toApiCondition(filter),
+		AutoEventFilter:   toApiCondition(resource.AutoEventFilter),
+		CustomEventFilter: toApiCondition(customEventFilter),
 	}
 }
 
@@ -121,13 +441,19 @@ func (r *triggerResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
-	trigger, err := r.client.CreateTrigger(toApiTrigger(plan))
+	client, err := clientInWorkspace(ctx, r.client, plan.AccountId, plan.ContainerId, plan.WorkspaceId)
 	if err != nil {
 		resp.Diagnostics.AddError("Error Creating Trigger", err.Error())
 		return
 	}
 
-	diags = resp.State.Set(ctx, toResourceTrigger(trigger))
+	trigger, err := client.CreateTrigger(toApiTrigger(plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Creating Trigger", err.Error())
+		return
+	}
+
+	diags = resp.State.Set(ctx, toResourceTrigger(trigger, plan))
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -143,7 +469,13 @@ func (r *triggerResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
-	trigger, err := r.client.Trigger(state.Id.ValueString())
+	client, err := clientInWorkspace(ctx, r.client, state.AccountId, state.ContainerId, state.WorkspaceId)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Trigger", err.Error())
+		return
+	}
+
+	trigger, err := client.Trigger(state.Id.ValueString())
 	if err == api.ErrNotExist {
 		resp.State.RemoveResource(ctx)
 		return
@@ -152,7 +484,7 @@ func (r *triggerResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
-	diags = resp.State.Set(ctx, toResourceTrigger(trigger))
+	diags = resp.State.Set(ctx, toResourceTrigger(trigger, state))
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -173,13 +505,19 @@ func (r *triggerResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
-	trigger, err := r.client.UpdateTrigger(state.Id.ValueString(), toApiTrigger(plan))
+	client, err := clientInWorkspace(ctx, r.client, state.AccountId, state.ContainerId, state.WorkspaceId)
 	if err != nil {
 		resp.Diagnostics.AddError("Error Updating Trigger", err.Error())
 		return
 	}
 
-	diags = resp.State.Set(ctx, toResourceTrigger(trigger))
+	trigger, err := client.UpdateTrigger(state.Id.ValueString(), toApiTrigger(plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Updating Trigger", err.Error())
+		return
+	}
+
+	diags = resp.State.Set(ctx, toResourceTrigger(trigger, plan))
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -196,7 +534,13 @@ func (r *triggerResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
-	err := r.client.DeleteTrigger(state.Id.ValueString())
+	client, err := clientInWorkspace(ctx, r.client, state.AccountId, state.ContainerId, state.WorkspaceId)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Deleting Trigger", err.Error())
+		return
+	}
+
+	err = client.DeleteTrigger(state.Id.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error Deleting Trigger", err.Error())
 		return
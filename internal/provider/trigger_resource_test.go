@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/tagmanager/v2"
+)
+
+var testPagePathFilter = resourceSimpleFilterModel{
+	Variable:   types.StringValue("page_path"),
+	Operator:   types.StringValue("contains"),
+	Value:      types.StringValue("/checkout"),
+	Negate:     types.BoolValue(true),
+	IgnoreCase: types.BoolValue(true),
+}
+
+var testEventFilter = resourceSimpleFilterModel{
+	Variable:   types.StringValue("event"),
+	Operator:   types.StringValue("equals"),
+	Value:      types.StringValue("purchase"),
+	Negate:     types.BoolNull(),
+	IgnoreCase: types.BoolNull(),
+}
+
+func testCustomEventTriggerPlan() resourceTriggerModel {
+	return resourceTriggerModel{
+		AccountId:    types.StringValue("6105084028"),
+		ContainerId:  types.StringValue("119458552"),
+		WorkspaceId:  types.StringValue("12"),
+		Name:         types.StringValue("test-purchase-outside-checkout"),
+		Type:         types.StringValue("customEvent"),
+		Id:           types.StringUnknown(),
+		Notes:        types.StringNull(),
+		SimpleFilter: []resourceSimpleFilterModel{testEventFilter, testPagePathFilter},
+		ApiJson:      types.StringUnknown(),
+	}
+}
+
+func TestExpandSimpleFilter(t *testing.T) {
+	condition, event := expandSimpleFilter(testPagePathFilter)
+	assert.False(t, event)
+	assert.Equal(t, "contains", condition.Type.ValueString())
+	assert.Equal(t, []*tagmanager.Parameter{
+		{Key: "arg0", Type: "template", Value: "{{Page Path}}"},
+		{Key: "arg1", Type: "template", Value: "/checkout"},
+		{Key: "ignore_case", Type: "boolean", Value: "true"},
+		{Key: "negate", Type: "boolean", Value: "true"},
+	}, toApiParameter(condition.Parameter))
+
+	condition, event = expandSimpleFilter(testEventFilter)
+	assert.True(t, event)
+	assert.Equal(t, "equals", condition.Type.ValueString())
+	assert.Equal(t, []*tagmanager.Parameter{
+		{Key: "arg0", Type: "template", Value: "{{_event}}"},
+		{Key: "arg1", Type: "template", Value: "purchase"},
+	}, toApiParameter(condition.Parameter))
+
+	// Other variables are referenced as written.
+	condition, event = expandSimpleFilter(resourceSimpleFilterModel{
+		Variable: types.StringValue("{{Order Total}}"),
+		Operator: types.StringValue("greater_or_equals"),
+		Value:    types.StringValue("100"),
+	})
+	assert.False(t, event)
+	assert.Equal(t, "greaterOrEquals", condition.Type.ValueString())
+	assert.Equal(t, "{{Order Total}}", toApiParameter(condition.Parameter)[0].Value)
+}
+
+func TestToApiTriggerRoutesSimpleFilters(t *testing.T) {
+	trigger := toApiTrigger(testCustomEventTriggerPlan())
+
+	require.Len(t, trigger.CustomEventFilter, 1)
+	assert.Equal(t, "{{_event}}", trigger.CustomEventFilter[0].Parameter[0].Value)
+	require.Len(t, trigger.Filter, 1)
+	assert.Equal(t, "{{Page Path}}", trigger.Filter[0].Parameter[0].Value)
+}
+
+func TestToResourceTriggerKeepsSimpleFilter(t *testing.T) {
+	var trigger tagmanager.Trigger
+	loadApiResponse(t, "trigger_custom_event.json", &trigger)
+
+	plan := testCustomEventTriggerPlan()
+	state := toResourceTrigger(&trigger, plan)
+
+	assert.True(t, plan.Equal(state), "state %+v differs from plan", state)
+	assert.Nil(t, state.Filter)
+	assert.Nil(t, state.CustomEventFilter)
+
+	// A refresh keeps the state as it is.
+	assert.True(t, state.Equal(toResourceTrigger(&trigger, state)))
+}
+
+func TestToResourceTriggerFallsBackToConditions(t *testing.T) {
+	var trigger tagmanager.Trigger
+	loadApiResponse(t, "trigger_custom_event.json", &trigger)
+
+	// A condition added outside of Terraform has no simple filter.
+	trigger.Filter = append(trigger.Filter, &tagmanager.Condition{
+		Type: "equals",
+		Parameter: []*tagmanager.Parameter{
+			{Key: "arg0", Type: "template", Value: "{{Page Hostname}}"},
+			{Key: "arg1", Type: "template", Value: "example.com"},
+		},
+	})
+	state := toResourceTrigger(&trigger, testCustomEventTriggerPlan())
+
+	assert.Nil(t, state.SimpleFilter)
+	assert.Len(t, state.Filter, 2)
+	assert.Len(t, state.CustomEventFilter, 1)
+
+	// So has an event condition moved into filter.
+	var moved tagmanager.Trigger
+	loadApiResponse(t, "trigger_custom_event.json", &moved)
+	moved.Filter = append(moved.Filter, moved.CustomEventFilter...)
+	moved.CustomEventFilter = nil
+	state = toResourceTrigger(&moved, testCustomEventTriggerPlan())
+	assert.Nil(t, state.SimpleFilter)
+	assert.Len(t, state.Filter, 2)
+
+	// Without simple filters, such as on import, conditions are kept as they
+	// are.
+	state = toResourceTrigger(&trigger, resourceTriggerModel{})
+	assert.Nil(t, state.SimpleFilter)
+	assert.Len(t, state.Filter, 2)
+}
@@ -0,0 +1,340 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/api/tagmanager/v2"
+)
+
+// customTemplateTypePrefix starts the types of tags and variables based on
+// custom templates, which are not in the catalogues.
+const customTemplateTypePrefix = "cvt_"
+
+// builtInTagTypes maps the built-in tag types to the parameter keys they
+// require.
+var builtInTagTypes = map[string][]string{
+	"awcc":    nil,
+	"awct":    {"conversionId", "conversionLabel"},
+	"awud":    nil,
+	"baut":    nil,
+	"bzi":     nil,
+	"cegg":    nil,
+	"crto":    nil,
+	"flc":     nil,
+	"fls":     nil,
+	"gaawc":   {"measurementId"},
+	"gaawe":   {"eventName"},
+	"gclidw":  nil,
+	"googtag": {"tagId"},
+	"hjtc":    nil,
+	"html":    {"html"},
+	"img":     {"url"},
+	"pntr":    nil,
+	"qcm":     nil,
+	"sp":      {"conversionId"},
+	"ua":      nil,
+}
+
+// builtInVariableTypes maps the built-in variable types to the parameter keys
+// they require.
+var builtInVariableTypes = map[string][]string{
+	"aev":  {"varType"},
+	"awec": nil,
+	"c":    {"value"},
+	"cid":  nil,
+	"ctv":  nil,
+	"d":    nil,
+	"dbg":  nil,
+	"e":    nil,
+	"ed":   nil,
+	"f":    nil,
+	"gas":  nil,
+	"gtcs": nil,
+	"gtes": nil,
+	"j":    {"name"},
+	"jsm":  {"javascript"},
+	"k":    {"name"},
+	"r":    nil,
+	"remm": {"input", "map"},
+	"rh":   nil,
+	"smm":  {"input", "map"},
+	"u":    nil,
+	"uv":   nil,
+	"v":    {"name"},
+	"vis":  nil,
+}
+
+// serverDefaultTagParameters maps tag types to the parameters, and their
+// values, that the API adds to tags of the type when they are not set.
+var serverDefaultTagParameters = map[string]map[string]string{
+	"awct": {
+		"enableConversionLinker":     "true",
+		"enableEnhancedConversion":   "false",
+		"enableNewCustomerReporting": "false",
+		"enableProductReporting":     "false",
+		"enableShippingData":         "false",
+		"rdp":                        "false",
+	},
+	"gaawc": {
+		"enableSendToServerContainer": "false",
+		"sendPageView":                "true",
+	},
+	"gaawe": {
+		"enhancedUserId":    "false",
+		"sendEcommerceData": "false",
+	},
+	"gclidw": {
+		"enableCookieOverrides": "false",
+		"enableCrossDomain":     "false",
+		"enableUrlPassthrough":  "false",
+	},
+	"html": {
+		"supportDocumentWrite": "false",
+	},
+	"img": {
+		"cacheBusterQueryParam": "gtmcb",
+		"useCacheBuster":        "true",
+	},
+	"sp": {
+		"customParamsFormat":       "NONE",
+		"enableConversionLinker":   "true",
+		"enableDynamicRemarketing": "false",
+		"enableOgtRmktParams":      "true",
+		"enableUserId":             "false",
+		"rdp":                      "false",
+	},
+}
+
+// serverDefaultVariableParameters maps variable types to the parameters, and
+// their values, that the API adds to variables of the type when they are not
+// set.
+var serverDefaultVariableParameters = map[string]map[string]string{
+	"aev":  {"setDefaultValue": "false"},
+	"k":    {"decodeCookie": "false"},
+	"remm": {"setDefaultValue": "false"},
+	"smm":  {"setDefaultValue": "false"},
+	"v": {
+		"dataLayerVersion": "2",
+		"setDefaultValue":  "false",
+	},
+}
+
+// builtInTriggerTypes lists the trigger types of the API. Triggers take their
+// settings from fields rather than parameters, so no keys are required.
+var builtInTriggerTypes = map[string][]string{
+	"always":                         nil,
+	"ampClick":                       nil,
+	"ampScroll":                      nil,
+	"ampTimer":                       nil,
+	"ampVisibility":                  nil,
+	"click":                          nil,
+	"consentInit":                    nil,
+	"customEvent":                    nil,
+	"domReady":                       nil,
+	"elementVisibility":              nil,
+	"firebaseAppException":           nil,
+	"firebaseAppUpdate":              nil,
+	"firebaseCampaign":               nil,
+	"firebaseFirstOpen":              nil,
+	"firebaseInAppPurchase":          nil,
+	"firebaseNotificationDismiss":    nil,
+	"firebaseNotificationForeground": nil,
+	"firebaseNotificationOpen":       nil,
+	"firebaseNotificationReceive":    nil,
+	"firebaseOsUpdate":               nil,
+	"firebaseSessionStart":           nil,
+	"firebaseUserEngagement":         nil,
+	"formSubmission":                 nil,
+	"historyChange":                  nil,
+	"init":                           nil,
+	"jsError":                        nil,
+	"linkClick":                      nil,
+	"pageview":                       nil,
+	"scrollDepth":                    nil,
+	"serverPageview":                 nil,
+	"timer":                          nil,
+	"triggerGroup":                   nil,
+	"windowLoaded":                   nil,
+	"youTubeVideo":                   nil,
+}
+
+// allowUnknownTypeSchema lets a resource use a type missing from the
+// catalogue, e.g. of a server container.
+var allowUnknownTypeSchema = schema.BoolAttribute{
+	Description: "Accept a type that is neither in the provider's catalogue of built-in types nor a custom template type, e.g. a type of server containers. Only the API checks such a type, when the resource is applied.",
+	Optional:    true,
+}
+
+// builtInTypeValidator checks that the type of the resource is in the
+// catalogue of its kind and that the parameters of the resource have the keys
+// that the type requires. Custom template types and, with allow_unknown_type,
+// types missing from the catalogue are not checked.
+type builtInTypeValidator struct {
+	kind      string
+	catalogue map[string][]string
+}
+
+func (v builtInTypeValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("value should be a built-in %s type or a custom template type starting with %s", v.kind, customTemplateTypePrefix)
+}
+
+func (v builtInTypeValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v builtInTypeValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	t := req.ConfigValue.ValueString()
+	if strings.HasPrefix(t, customTemplateTypePrefix) {
+		return
+	}
+
+	required, ok := v.catalogue[t]
+	if !ok {
+		detail := fmt.Sprintf("%q is not a known built-in %s type.", t, v.kind)
+		if suggestions := closestTypes(t, v.catalogue); len(suggestions) > 0 {
+			detail += fmt.Sprintf(" Did you mean %s?", strings.Join(suggestions, " or "))
+		}
+		detail += fmt.Sprintf(" Types of custom templates start with %s. Set allow_unknown_type to use a type missing from the catalogue.", customTemplateTypePrefix)
+
+		var allow types.Bool
+		if diags := req.Config.GetAttribute(ctx, req.Path.ParentPath().AtName("allow_unknown_type"), &allow); diags.HasError() || allow.IsUnknown() || allow.ValueBool() {
+			return
+		}
+
+		resp.Diagnostics.AddAttributeError(req.Path, fmt.Sprintf("Unknown %s%s Type", strings.ToUpper(v.kind[:1]), v.kind[1:]), detail)
+		return
+	}
+
+	if len(required) == 0 {
+		return
+	}
+
+	keys, at, ok := configParameterKeys(ctx, req.Config, req.Path.ParentPath())
+	if !ok {
+		return
+	}
+
+	for _, key := range required {
+		if !keys[key] {
+			resp.Diagnostics.AddAttributeError(at, "Missing Parameter", fmt.Sprintf("A %s of type %s requires the parameter %q.", v.kind, t, key))
+		}
+	}
+}
+
+// configParameterKeys returns the top-level parameter keys of the resource at
+// parent, and the attribute they are set with. It returns false while the
+// keys are not known yet or the parameters do not decode.
+func configParameterKeys(ctx context.Context, config tfsdk.Config, parent path.Path) (map[string]bool, path.Path, bool) {
+	keys := map[string]bool{}
+
+	decoders := map[string]func(string) ([]*tagmanager.Parameter, error){
+		"parameters":     decodeParametersObject,
+		"parameter_json": decodeParameterJson,
+	}
+	for name, decode := range decoders {
+		var value types.String
+		diags := config.GetAttribute(ctx, parent.AtName(name), &value)
+		if diags.HasError() || value.IsNull() {
+			continue
+		}
+
+		if value.IsUnknown() {
+			return nil, parent.AtName(name), false
+		}
+
+		parameter, err := decode(value.ValueString())
+		if err != nil {
+			return nil, parent.AtName(name), false
+		}
+
+		for _, p := range parameter {
+			keys[p.Key] = true
+		}
+		return keys, parent.AtName(name), true
+	}
+
+	at := parent.AtName("parameter")
+
+	var value attr.Value
+	if d := config.GetAttribute(ctx, at, &value); d.HasError() || value == nil || value.IsUnknown() {
+		return nil, at, false
+	}
+
+	parameter, _ := configParameterModels(ctx, config, at)
+	for _, p := range parameter {
+		if p.Key.IsUnknown() {
+			return nil, at, false
+		}
+		keys[p.Key.ValueString()] = true
+	}
+
+	return keys, at, true
+}
+
+// closestTypes returns the types in the catalogue that are fewest edits, and
+// at most two, away from t.
+func closestTypes(t string, catalogue map[string][]string) []string {
+	var closest []string
+	distance := 3
+
+	for candidate := range catalogue {
+		d := editDistance(t, candidate)
+		if d > 2 {
+			continue
+		}
+
+		if d < distance {
+			closest, distance = []string{candidate}, d
+		} else if d == distance {
+			closest = append(closest, candidate)
+		}
+	}
+
+	sort.Strings(closest)
+	return closest
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			current[j] = previous[j-1] + cost
+			if d := previous[j] + 1; d < current[j] {
+				current[j] = d
+			}
+			if d := current[j-1] + 1; d < current[j] {
+				current[j] = d
+			}
+		}
+
+		previous = current
+	}
+
+	return previous[len(b)]
+}
@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testConfig returns a configuration of a resource with the given schema
+// attributes, whose other attributes are null.
+func testConfig(attributes map[string]schema.Attribute, values map[string]tftypes.Value) tfsdk.Config {
+	s := schema.Schema{Attributes: attributes}
+	objectType := s.Type().TerraformType(context.Background()).(tftypes.Object)
+
+	return tfsdk.Config{Schema: s, Raw: testObject(objectType, values)}
+}
+
+// testParameterSet returns a value of the parameter attribute with template
+// parameters of the given keys.
+func testParameterSet(keys ...string) tftypes.Value {
+	setType := parameterSchema.GetType().TerraformType(context.Background()).(tftypes.Set)
+	elementType := setType.ElementType.(tftypes.Object)
+
+	elements := make([]tftypes.Value, len(keys))
+	for i, key := range keys {
+		elements[i] = testObject(elementType, map[string]tftypes.Value{
+			"key":   testString(key),
+			"type":  testString("template"),
+			"value": testString("x"),
+		})
+	}
+
+	return tftypes.NewValue(setType, elements)
+}
+
+func validateTagType(t string, values map[string]tftypes.Value) diag.Diagnostics {
+	values["type"] = testString(t)
+	req := validator.StringRequest{
+		Path:        path.Root("type"),
+		ConfigValue: types.StringValue(t),
+		Config:      testConfig(tagResourceSchemaAttributes, values),
+	}
+
+	var resp validator.StringResponse
+	builtInTypeValidator{"tag", builtInTagTypes}.ValidateString(context.Background(), req, &resp)
+	return resp.Diagnostics
+}
+
+func TestBuiltInTypeValidatorAcceptsKnownTypes(t *testing.T) {
+	assert.Empty(t, validateTagType("html", map[string]tftypes.Value{"parameter": testParameterSet("html")}))
+	assert.Empty(t, validateTagType("cvt_119458552_21", map[string]tftypes.Value{}))
+}
+
+func TestBuiltInTypeValidatorRejectsUnknownTypes(t *testing.T) {
+	diags := validateTagType("gaew", map[string]tftypes.Value{"parameter": testParameterSet("eventName")})
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.SeverityError, diags[0].Severity())
+	assert.Equal(t, "Unknown Tag Type", diags[0].Summary())
+	assert.Contains(t, diags[0].Detail(), "Did you mean gaawc or gaawe?")
+	assert.Equal(t, path.Root("type"), diags[0].(diag.DiagnosticWithPath).Path())
+
+	diags = validateTagType("sgtmgaaw", map[string]tftypes.Value{})
+	require.Len(t, diags, 1)
+	assert.Equal(t, diag.SeverityError, diags[0].Severity())
+}
+
+func TestBuiltInTypeValidatorAllowsUnknownTypes(t *testing.T) {
+	// Types missing from the catalogue, e.g. of server containers, can be
+	// opted into.
+	assert.Empty(t, validateTagType("sgtmgaaw", map[string]tftypes.Value{"allow_unknown_type": tftypes.NewValue(tftypes.Bool, true)}))
+	assert.Empty(t, validateTagType("sgtmgaaw", map[string]tftypes.Value{"allow_unknown_type": tftypes.NewValue(tftypes.Bool, tftypes.UnknownValue)}))
+	assert.Len(t, validateTagType("sgtmgaaw", map[string]tftypes.Value{"allow_unknown_type": tftypes.NewValue(tftypes.Bool, false)}), 1)
+}
+
+func TestBuiltInTypeValidatorChecksRequiredParameters(t *testing.T) {
+	for name, values := range map[string]map[string]tftypes.Value{
+		"parameter":      {"parameter": testParameterSet("eventName")},
+		"parameter_json": {"parameter_json": testString(`[{"key": "eventName", "type": "template", "value": "purchase"}]`)},
+		"parameters":     {"parameters": testString(`{"eventName": "purchase"}`)},
+	} {
+		assert.Empty(t, validateTagType("gaawe", values), name)
+	}
+
+	for name, values := range map[string]map[string]tftypes.Value{
+		"parameter":      {"parameter": testParameterSet("sendEcommerceData")},
+		"parameter_json": {"parameter_json": testString(`[{"key": "sendEcommerceData", "type": "boolean", "value": "true"}]`)},
+		"parameters":     {"parameters": testString(`{"sendEcommerceData": true}`)},
+	} {
+		diags := validateTagType("gaawe", values)
+		require.Len(t, diags, 1, name)
+		assert.Equal(t, "Missing Parameter", diags[0].Summary(), name)
+		assert.Contains(t, diags[0].Detail(), `"eventName"`, name)
+
+		withPath, ok := diags[0].(diag.DiagnosticWithPath)
+		require.True(t, ok, name)
+		assert.Equal(t, path.Root(name), withPath.Path(), name)
+	}
+
+	// Unknown parameters are not checked yet.
+	diags := validateTagType("gaawe", map[string]tftypes.Value{"parameters": tftypes.NewValue(tftypes.String, tftypes.UnknownValue)})
+	assert.Empty(t, diags)
+}
+
+func TestClosestTypes(t *testing.T) {
+	assert.Equal(t, []string{"html"}, closestTypes("htlm", builtInTagTypes))
+	assert.Equal(t, []string{"gaawc", "gaawe"}, closestTypes("gaaw", builtInTagTypes))
+	assert.Equal(t, []string{"smm"}, closestTypes("smm", builtInVariableTypes))
+	assert.Empty(t, closestTypes("facebookPixel", builtInTagTypes))
+}
+
+func TestEditDistance(t *testing.T) {
+	assert.Equal(t, 0, editDistance("gaawe", "gaawe"))
+	assert.Equal(t, 1, editDistance("gaawe", "gaawc"))
+	assert.Equal(t, 2, editDistance("htlm", "html"))
+	assert.Equal(t, 3, editDistance("", "img"))
+}
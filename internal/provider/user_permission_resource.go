@@ -0,0 +1,228 @@
+package provider
+
+import (
+	"context"
+	"terraform-provider-google-tag-manager/internal/api"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/api/tagmanager/v2"
+)
+
+var (
+	_ resource.ResourceWithConfigure = &userPermissionResource{}
+)
+
+func NewUserPermissionResource() resource.Resource {
+	return &userPermissionResource{}
+}
+
+type userPermissionResource struct {
+	client *api.ClientInWorkspace
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *userPermissionResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(*api.ClientInWorkspace)
+}
+
+// Metadata returns the resource type name.
+func (r *userPermissionResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_permission"
+}
+
+var userPermissionResourceSchemaAttributes = map[string]schema.Attribute{
+	"account_id": accountIdSchema,
+	"email_address": schema.StringAttribute{
+		Description:   "The email address of the user.",
+		Required:      true,
+		PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+	},
+	"account_access": schema.StringAttribute{
+		Description: "The account permission of the user: noAccess, user or admin.",
+		Required:    true,
+	},
+	"container_access": schema.SetNestedAttribute{
+		Description: "The container permissions of the user.",
+		Optional:    true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"container_id": schema.StringAttribute{
+					Description: "The ID of the container.",
+					Required:    true,
+				},
+				"permission": schema.StringAttribute{
+					Description: "The container permission: noAccess, read, edit, approve or publish.",
+					Required:    true,
+				},
+			},
+		},
+	},
+	"id": schema.StringAttribute{
+		Description:   "The ID of the user permission.",
+		Computed:      true,
+		PlanModifiers: idPlanModifiers,
+	},
+}
+
+// Schema defines the schema for the resource.
+func (r *userPermissionResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{Attributes: userPermissionResourceSchemaAttributes}
+}
+
+type resourceContainerAccessModel struct {
+	ContainerId types.String `tfsdk:"container_id"`
+	Permission  types.String `tfsdk:"permission"`
+}
+
+type resourceUserPermissionModel struct {
+	AccountId       types.String                   `tfsdk:"account_id"`
+	EmailAddress    types.String                   `tfsdk:"email_address"`
+	AccountAccess   types.String                   `tfsdk:"account_access"`
+	ContainerAccess []resourceContainerAccessModel `tfsdk:"container_access"`
+	Id              types.String                   `tfsdk:"id"`
+}
+
+func toResourceUserPermission(permission *tagmanager.UserPermission, prior resourceUserPermissionModel) resourceUserPermissionModel {
+	var accountAccess string
+	if permission.AccountAccess != nil {
+		accountAccess = permission.AccountAccess.Permission
+	}
+
+	var containerAccess []resourceContainerAccessModel
+	if len(permission.ContainerAccess) == 0 && prior.ContainerAccess != nil && len(prior.ContainerAccess) == 0 {
+		containerAccess = prior.ContainerAccess
+	}
+	for _, access := range permission.ContainerAccess {
+		containerAccess = append(containerAccess, resourceContainerAccessModel{
+			ContainerId: types.StringValue(access.ContainerId),
+			Permission:  types.StringValue(access.Permission),
+		})
+	}
+
+	return resourceUserPermissionModel{
+		AccountId:       types.StringValue(permission.AccountId),
+		EmailAddress:    types.StringValue(permission.EmailAddress),
+		AccountAccess:   priorStringValue(accountAccess, prior.AccountAccess),
+		ContainerAccess: containerAccess,
+		Id:              types.StringValue(api.UserPermissionId(permission)),
+	}
+}
+
+func toApiUserPermission(resource resourceUserPermissionModel) *tagmanager.UserPermission {
+	var containerAccess []*tagmanager.ContainerAccess
+	for _, access := range resource.ContainerAccess {
+		containerAccess = append(containerAccess, &tagmanager.ContainerAccess{
+			ContainerId: access.ContainerId.ValueString(),
+			Permission:  access.Permission.ValueString(),
+		})
+	}
+
+	return &tagmanager.UserPermission{
+		EmailAddress:    resource.EmailAddress.ValueString(),
+		AccountAccess:   &tagmanager.AccountAccess{Permission: resource.AccountAccess.ValueString()},
+		ContainerAccess: containerAccess,
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *userPermissionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan resourceUserPermissionModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.client.WithContext(ctx).InContainer(plan.AccountId.ValueString(), "")
+	permission, err := client.CreateUserPermission(toApiUserPermission(plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Creating User Permission", err.Error())
+		return
+	}
+
+	diags = resp.State.Set(ctx, toResourceUserPermission(permission, plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *userPermissionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state resourceUserPermissionModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.client.WithContext(ctx).InContainer(state.AccountId.ValueString(), "")
+	permission, err := client.UserPermission(state.Id.ValueString())
+	if err == api.ErrNotExist {
+		resp.State.RemoveResource(ctx)
+		return
+	} else if err != nil {
+		resp.Diagnostics.AddError("Error Reading User Permission", err.Error())
+		return
+	}
+
+	diags = resp.State.Set(ctx, toResourceUserPermission(permission, state))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *userPermissionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state resourceUserPermissionModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.client.WithContext(ctx).InContainer(state.AccountId.ValueString(), "")
+	permission, err := client.UpdateUserPermission(state.Id.ValueString(), toApiUserPermission(plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Updating User Permission", err.Error())
+		return
+	}
+
+	diags = resp.State.Set(ctx, toResourceUserPermission(permission, plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *userPermissionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state resourceUserPermissionModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.client.WithContext(ctx).InContainer(state.AccountId.ValueString(), "")
+	err := client.DeleteUserPermission(state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error Deleting User Permission", err.Error())
+		return
+	}
+}
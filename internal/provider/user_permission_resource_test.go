@@ -0,0 +1,24 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/tagmanager/v2"
+)
+
+func TestToResourceUserPermissionKeepsEmptyContainerAccess(t *testing.T) {
+	permission := &tagmanager.UserPermission{
+		AccountId:     "6105084028",
+		EmailAddress:  "test@example.com",
+		AccountAccess: &tagmanager.AccountAccess{Permission: "user"},
+	}
+
+	state := toResourceUserPermission(permission, resourceUserPermissionModel{ContainerAccess: []resourceContainerAccessModel{}})
+	assert.Equal(t, []resourceContainerAccessModel{}, state.ContainerAccess)
+	assert.Equal(t, types.StringValue("user"), state.AccountAccess)
+
+	state = toResourceUserPermission(permission, resourceUserPermissionModel{})
+	assert.Nil(t, state.ContainerAccess)
+}
@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"context"
+	"terraform-provider-google-tag-manager/internal/api"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSourceWithConfigure = &userPermissionsDataSource{}
+)
+
+func NewUserPermissionsDataSource() datasource.DataSource {
+	return &userPermissionsDataSource{}
+}
+
+type userPermissionsDataSource struct {
+	client *api.ClientInWorkspace
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *userPermissionsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.client = req.ProviderData.(*api.ClientInWorkspace)
+}
+
+// Metadata returns the data source type name.
+func (d *userPermissionsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_permissions"
+}
+
+// Schema defines the schema for the data source.
+func (d *userPermissionsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists all user permissions of an account.",
+		Attributes: map[string]schema.Attribute{
+			"account_id": schema.StringAttribute{
+				Description: "GTM Account ID. Defaults to the account_id of the provider.",
+				Optional:    true,
+				Computed:    true,
+			},
+			"user_permission": schema.ListNestedAttribute{
+				Description: "The user permissions of the account.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"account_id": schema.StringAttribute{
+							Description: "GTM Account ID.",
+							Computed:    true,
+						},
+						"email_address": schema.StringAttribute{
+							Description: "The email address of the user.",
+							Computed:    true,
+						},
+						"account_access": schema.StringAttribute{
+							Description: "The account permission of the user.",
+							Computed:    true,
+						},
+						"container_access": schema.SetNestedAttribute{
+							Description: "The container permissions of the user.",
+							Computed:    true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"container_id": schema.StringAttribute{
+										Description: "The ID of the container.",
+										Computed:    true,
+									},
+									"permission": schema.StringAttribute{
+										Description: "The container permission.",
+										Computed:    true,
+									},
+								},
+							},
+						},
+						"id": schema.StringAttribute{
+							Description: "The ID of the user permission.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+type dataSourceUserPermissionsModel struct {
+	AccountId      types.String                  `tfsdk:"account_id"`
+	UserPermission []resourceUserPermissionModel `tfsdk:"user_permission"`
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *userPermissionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config dataSourceUserPermissionsModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := d.client.WithContext(ctx).InContainer(config.AccountId.ValueString(), "")
+	permissions, err := client.ListUserPermissions()
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading User Permissions", err.Error())
+		return
+	}
+
+	state := dataSourceUserPermissionsModel{
+		AccountId:      types.StringValue(client.Options.AccountId),
+		UserPermission: make([]resourceUserPermissionModel, len(permissions)),
+	}
+	for i, permission := range permissions {
+		state.UserPermission[i] = toResourceUserPermission(permission, resourceUserPermissionModel{})
+	}
+
+	diags = resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,238 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"terraform-provider-google-tag-manager/internal/api"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// implicitVariableNames can be referenced in every workspace without being
+// defined or enabled.
+var implicitVariableNames = []string{"_event"}
+
+// unreferencingAttributes are the top-level attributes whose values are not
+// sent to tags as templates and are therefore not checked for references.
+var unreferencingAttributes = map[string]bool{
+	"account_id":   true,
+	"container_id": true,
+	"workspace_id": true,
+	"id":           true,
+	"api_json":     true,
+	"name":         true,
+	"notes":        true,
+	"type":         true,
+}
+
+// variableReferences returns the names of the variables referenced in s.
+// Malformed references are skipped, validation reports them.
+func variableReferences(s string) []string {
+	var names []string
+
+	for {
+		start := strings.Index(s, "{{")
+		if start < 0 {
+			return names
+		}
+
+		s = s[start+2:]
+		end := strings.Index(s, "}}")
+		if end < 0 {
+			return names
+		}
+
+		if name := s[:end]; strings.TrimSpace(name) != "" && !strings.ContainsAny(name, "{}\n") {
+			names = append(names, name)
+		}
+		s = s[end+2:]
+	}
+}
+
+// planVariableReferences returns the variables referenced by the known string
+// values of the plan, by the top-level attribute they are set in.
+func planVariableReferences(plan tfsdk.Plan) (map[string][]string, error) {
+	references := map[string][]string{}
+
+	err := tftypes.Walk(plan.Raw, func(p *tftypes.AttributePath, v tftypes.Value) (bool, error) {
+		steps := p.Steps()
+		if len(steps) == 0 {
+			return true, nil
+		}
+
+		attribute, ok := steps[0].(tftypes.AttributeName)
+		if !ok || unreferencingAttributes[string(attribute)] {
+			return false, nil
+		}
+
+		if !v.IsKnown() || v.IsNull() || !v.Type().Is(tftypes.String) {
+			return true, nil
+		}
+
+		var s string
+		if err := v.As(&s); err != nil {
+			return false, err
+		}
+		if names := variableReferences(s); len(names) > 0 {
+			references[string(attribute)] = append(references[string(attribute)], names...)
+		}
+
+		return true, nil
+	})
+
+	return references, err
+}
+
+// variableNameCache holds the names of the variables and enabled built-in
+// variables of each workspace, so that a plan lists them once rather than for
+// every resource.
+type variableNameCache struct {
+	mu    sync.Mutex
+	names map[string]map[string]bool
+}
+
+var workspaceVariableNames = &variableNameCache{names: map[string]map[string]bool{}}
+
+// get returns the variable names of the workspace of the client, or nil when
+// the workspace does not exist yet.
+func (c *variableNameCache) get(client *api.ClientInWorkspace) (map[string]bool, error) {
+	workspaceId, err := client.ExistingWorkspaceId()
+	if err != nil || workspaceId == "" {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := client.Options.AccountId + "/" + client.Options.ContainerId + "/" + workspaceId
+	if names, ok := c.names[key]; ok {
+		return names, nil
+	}
+
+	variables, err := client.ListVariables()
+	if err != nil {
+		return nil, err
+	}
+
+	builtInVariables, err := client.ListBuiltInVariables()
+	if err != nil {
+		return nil, err
+	}
+
+	names := map[string]bool{}
+	for _, name := range implicitVariableNames {
+		names[name] = true
+	}
+	for _, variable := range variables {
+		names[variable.Name] = true
+	}
+	for _, variable := range builtInVariables {
+		names[variable.Name] = true
+	}
+
+	c.names[key] = names
+	return names, nil
+}
+
+// planClient returns the client of the workspace a resource is planned in.
+// Locations left out of the configuration are those of the provider. It
+// returns nil when the location is not known yet.
+func planClient(ctx context.Context, client *api.ClientInWorkspace, req resource.ModifyPlanRequest) (*api.ClientInWorkspace, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	ids := make([]types.String, 3)
+
+	for i, name := range []string{"account_id", "container_id", "workspace_id"} {
+		var planned, configured types.String
+		diags.Append(req.Plan.GetAttribute(ctx, path.Root(name), &planned)...)
+		diags.Append(req.Config.GetAttribute(ctx, path.Root(name), &configured)...)
+		if diags.HasError() {
+			return nil, diags
+		}
+
+		switch {
+		case !planned.IsUnknown():
+			ids[i] = planned
+		case configured.IsUnknown():
+			return nil, diags
+		default:
+			// Unknown until created, but created where the provider
+			// points to.
+			ids[i] = types.StringNull()
+		}
+	}
+
+	client, err := clientInWorkspace(ctx, client, ids[0], ids[1], ids[2])
+	if err != nil {
+		return nil, diags
+	}
+
+	return client, diags
+}
+
+// checkPlanVariableReferences reports the variable references in the plan that
+// are neither variables of the workspace nor enabled built-in variables. They
+// are warnings unless strict_variable_references is set. Variables created in
+// the same apply do not exist yet and are reported too, whatever the order in
+// which Terraform plans the resources.
+func checkPlanVariableReferences(ctx context.Context, client *api.ClientInWorkspace, req resource.ModifyPlanRequest) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if client == nil || req.Plan.Raw.IsNull() {
+		return diags
+	}
+
+	references, err := planVariableReferences(req.Plan)
+	if err != nil || len(references) == 0 {
+		return diags
+	}
+
+	client, d := planClient(ctx, client, req)
+	diags.Append(d...)
+	if client == nil {
+		return diags
+	}
+
+	names, err := workspaceVariableNames.get(client)
+	if err != nil {
+		diags.AddWarning("Unable to Check Variable References", err.Error())
+		return diags
+	}
+	if names == nil {
+		return diags
+	}
+
+	attributes := make([]string, 0, len(references))
+	for attribute := range references {
+		attributes = append(attributes, attribute)
+	}
+	sort.Strings(attributes)
+
+	for _, attribute := range attributes {
+		reported := map[string]bool{}
+
+		for _, name := range references[attribute] {
+			if names[name] || reported[name] {
+				continue
+			}
+			reported[name] = true
+
+			summary := "Unresolved Variable Reference"
+			detail := fmt.Sprintf("{{%s}} is neither a variable of the workspace nor an enabled built-in variable.", name)
+			if client.Options.StrictVariableReferences {
+				diags.AddAttributeError(path.Root(attribute), summary, detail)
+			} else {
+				diags.AddAttributeWarning(path.Root(attribute), summary, detail)
+			}
+		}
+	}
+
+	return diags
+}
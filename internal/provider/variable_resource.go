@@ -6,12 +6,16 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"google.golang.org/api/tagmanager/v2"
 )
 
 var (
-	_ resource.ResourceWithConfigure = &variableResource{}
+	_ resource.ResourceWithConfigure      = &variableResource{}
+	_ resource.ResourceWithValidateConfig = &variableResource{}
+	_ resource.ResourceWithModifyPlan     = &variableResource{}
+	_ resource.ResourceWithUpgradeState   = &variableResource{}
 )
 
 func NewVariableResource() resource.Resource {
@@ -37,75 +41,141 @@ func (r *variableResource) Metadata(_ context.Context, req resource.MetadataRequ
 }
 
 var variableResourceSchemaAttributes = map[string]schema.Attribute{
+	"account_id":   accountIdSchema,
+	"container_id": containerIdSchema,
+	"workspace_id": workspaceIdSchema,
 	"name": schema.StringAttribute{
 		Description: "The name of the variable.",
 		Required:    true,
 	},
 	"type": schema.StringAttribute{
-		Description: "The type of the variable.",
-		Required:    true,
+		Description:   "The type of the variable, a built-in type or the type of a custom template (cvt_...). Changing it recreates the variable.",
+		Required:      true,
+		PlanModifiers: immutablePlanModifiers,
+		Validators:    []validator.String{builtInTypeValidator{"variable", builtInVariableTypes}},
 	},
+	"allow_unknown_type": allowUnknownTypeSchema,
 	"id": schema.StringAttribute{
-		Description: "The ID of the variable.",
-		Computed:    true,
+		Description:   "The ID of the variable.",
+		Computed:      true,
+		PlanModifiers: idPlanModifiers,
 	},
 	"notes": schema.StringAttribute{
 		Description: "The notes of the variable.",
 		Optional:    true,
 	},
-	"parameter": parameterSchema,
+	"parameter":      parameterSchema,
+	"parameter_json": parameterJsonSchema,
+	"parameters":     parametersSchema,
+	"api_json":       apiJsonSchema,
 }
 
 // Schema defines the schema for the resource.
 func (r *variableResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		Version:    parameterSchemaVersion,
 		Attributes: variableResourceSchemaAttributes,
 	}
 }
 
+// UpgradeState upgrades the state from prior schema versions.
+func (r *variableResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: parameterStateUpgrader(variableResourceSchemaAttributes, func() interface{} { return &resourceVariableModel{} }),
+	}
+}
+
+// ValidateConfig checks the parameters of the variable.
+func (r *variableResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(validateConfigParameters(ctx, req.Config)...)
+}
+
+// ModifyPlan checks the variable references of the variable against the
+// workspace and renders api_json.
+func (r *variableResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resp.Diagnostics.Append(checkPlanVariableReferences(ctx, r.client, req)...)
+
+	var plan resourceVariableModel
+	planApiJson(ctx, req, resp, &plan, func() types.String { return variableApiJson(plan) })
+}
+
 type resourceVariableModel struct {
-	Name      types.String             `tfsdk:"name"`
-	Type      types.String             `tfsdk:"type"`
-	Id        types.String             `tfsdk:"id"`
-	Notes     types.String             `tfsdk:"notes"`
-	Parameter []ResourceParameterModel `tfsdk:"parameter"`
+	AccountId        types.String             `tfsdk:"account_id"`
+	ContainerId      types.String             `tfsdk:"container_id"`
+	WorkspaceId      types.String             `tfsdk:"workspace_id"`
+	Name             types.String             `tfsdk:"name"`
+	Type             types.String             `tfsdk:"type"`
+	Id               types.String             `tfsdk:"id"`
+	Notes            types.String             `tfsdk:"notes"`
+	Parameter        []ResourceParameterModel `tfsdk:"parameter"`
+	ParameterJson    types.String             `tfsdk:"parameter_json"`
+	Parameters       types.String             `tfsdk:"parameters"`
+	ApiJson          types.String             `tfsdk:"api_json"`
+	AllowUnknownType types.Bool               `tfsdk:"allow_unknown_type"`
 }
 
 // Equal compares the two models and returns true if they are equal.
 func (m resourceVariableModel) Equal(o resourceVariableModel) bool {
-	if !m.Name.Equal(o.Name) ||
+	if (!m.AccountId.IsUnknown() && !m.AccountId.Equal(o.AccountId)) ||
+		(!m.ContainerId.IsUnknown() && !m.ContainerId.Equal(o.ContainerId)) ||
+		(!m.WorkspaceId.IsUnknown() && !m.WorkspaceId.Equal(o.WorkspaceId)) ||
+		!m.Name.Equal(o.Name) ||
 		!m.Type.Equal(o.Type) ||
 		(!m.Id.IsUnknown() && !m.Id.Equal(o.Id)) ||
 		!m.Notes.Equal(o.Notes) ||
-		len(m.Parameter) != len(o.Parameter) {
+		!m.ParameterJson.Equal(o.ParameterJson) ||
+		!m.Parameters.Equal(o.Parameters) {
 		return false
 	}
 
-	for i := range m.Parameter {
-		if !m.Parameter[i].Equal(o.Parameter[i]) {
-			return false
-		}
+	if !parameterSetsEqual(m.Parameter, o.Parameter) {
+		return false
 	}
 
 	return true
 }
 
-func toResourceVariable(variable *tagmanager.Variable) resourceVariableModel {
-	return resourceVariableModel{
-		Name:      types.StringValue(variable.Name),
-		Type:      types.StringValue(variable.Type),
-		Id:        types.StringValue(variable.VariableId),
-		Notes:     nullableStringValue(variable.Notes),
-		Parameter: toResourceParameter(variable.Parameter),
+func toResourceVariable(variable *tagmanager.Variable, prior resourceVariableModel) resourceVariableModel {
+	parameter, parameterJson, parameters := toResourceParameters(variable.Parameter, serverDefaultVariableParameters[variable.Type], prior.Parameter, prior.ParameterJson, prior.Parameters)
+
+	state := resourceVariableModel{
+		AccountId:        types.StringValue(variable.AccountId),
+		ContainerId:      types.StringValue(variable.ContainerId),
+		WorkspaceId:      types.StringValue(variable.WorkspaceId),
+		Name:             types.StringValue(variable.Name),
+		Type:             types.StringValue(variable.Type),
+		Id:               types.StringValue(variable.VariableId),
+		Notes:            priorStringValue(variable.Notes, prior.Notes),
+		Parameter:        parameter,
+		ParameterJson:    parameterJson,
+		Parameters:       parameters,
+		ApiJson:          prior.ApiJson,
+		AllowUnknownType: prior.AllowUnknownType,
 	}
+
+	if state.ApiJson.IsNull() || state.ApiJson.IsUnknown() || !prior.Equal(state) {
+		state.ApiJson = variableApiJson(state)
+	}
+
+	return state
+}
+
+// variableApiJson renders the variable that toApiVariable sends for the
+// resource.
+func variableApiJson(resource resourceVariableModel) types.String {
+	variable := toApiVariable(resource)
+	variable.VariableId = ""
+	variable.Parameter = canonicalParameters(variable.Parameter)
+
+	return apiJsonValue(variable)
 }
 func toApiVariable(resource resourceVariableModel) *tagmanager.Variable {
 	return &tagmanager.Variable{
 		Name:       resource.Name.ValueString(),
 		Type:       resource.Type.ValueString(),
-		VariableId: resource.Id.String(),
+		VariableId: resource.Id.ValueString(),
 		Notes:      resource.Notes.ValueString(),
-		Parameter:  toApiParameter(resource.Parameter),
+		Parameter:  toApiParameters(resource.Parameter, resource.ParameterJson, resource.Parameters),
 	}
 }
 
@@ -118,13 +188,19 @@ func (r *variableResource) Create(ctx context.Context, req resource.CreateReques
 		return
 	}
 
-	variable, err := r.client.CreateVariable(toApiVariable(plan))
+	client, err := clientInWorkspace(ctx, r.client, plan.AccountId, plan.ContainerId, plan.WorkspaceId)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Creating Variable", err.Error())
+		return
+	}
+
+	variable, err := client.CreateVariable(toApiVariable(plan))
 	if err != nil {
 		resp.Diagnostics.AddError("Error Creating Variable", err.Error())
 		return
 	}
 
-	diags = resp.State.Set(ctx, toResourceVariable(variable))
+	diags = resp.State.Set(ctx, toResourceVariable(variable, plan))
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -140,7 +216,13 @@ func (r *variableResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
-	variable, err := r.client.Variable(state.Id.ValueString())
+	client, err := clientInWorkspace(ctx, r.client, state.AccountId, state.ContainerId, state.WorkspaceId)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Variable", err.Error())
+		return
+	}
+
+	variable, err := client.Variable(state.Id.ValueString())
 	if err == api.ErrNotExist {
 		resp.State.RemoveResource(ctx)
 		return
@@ -149,7 +231,7 @@ func (r *variableResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
-	diags = resp.State.Set(ctx, toResourceVariable(variable))
+	diags = resp.State.Set(ctx, toResourceVariable(variable, state))
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -170,13 +252,19 @@ func (r *variableResource) Update(ctx context.Context, req resource.UpdateReques
 		return
 	}
 
-	variable, err := r.client.UpdateVariable(state.Id.ValueString(), toApiVariable(plan))
+	client, err := clientInWorkspace(ctx, r.client, state.AccountId, state.ContainerId, state.WorkspaceId)
 	if err != nil {
 		resp.Diagnostics.AddError("Error Updating Variable", err.Error())
 		return
 	}
 
-	diags = resp.State.Set(ctx, toResourceVariable(variable))
+	variable, err := client.UpdateVariable(state.Id.ValueString(), toApiVariable(plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Updating Variable", err.Error())
+		return
+	}
+
+	diags = resp.State.Set(ctx, toResourceVariable(variable, plan))
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -193,7 +281,13 @@ func (r *variableResource) Delete(ctx context.Context, req resource.DeleteReques
 		return
 	}
 
-	err := r.client.DeleteVariable(state.Id.ValueString())
+	client, err := clientInWorkspace(ctx, r.client, state.AccountId, state.ContainerId, state.WorkspaceId)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Deleting Variable", err.Error())
+		return
+	}
+
+	err = client.DeleteVariable(state.Id.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error Deleting Variable", err.Error())
 		return
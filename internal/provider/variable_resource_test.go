@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/tagmanager/v2"
+)
+
+func TestToResourceVariableIgnoresServerDefaults(t *testing.T) {
+	var variable tagmanager.Variable
+	loadApiResponse(t, "variable_v.json", &variable)
+
+	plan := resourceVariableModel{
+		AccountId:     types.StringUnknown(),
+		ContainerId:   types.StringUnknown(),
+		WorkspaceId:   types.StringUnknown(),
+		Name:          types.StringValue("test-data-layer"),
+		Type:          types.StringValue("v"),
+		Id:            types.StringUnknown(),
+		Notes:         types.StringNull(),
+		Parameter:     []ResourceParameterModel{templateParameter("name", types.StringValue("ecommerce.value"))},
+		ParameterJson: types.StringNull(),
+		Parameters:    types.StringNull(),
+	}
+	state := toResourceVariable(&variable, plan)
+
+	assert.True(t, plan.Equal(state), "state %+v differs from plan", state)
+	assert.True(t, state.Equal(toResourceVariable(&variable, state)))
+}
+
+func TestToResourceVariableKeepsEmptyParameterValues(t *testing.T) {
+	var variable tagmanager.Variable
+	loadApiResponse(t, "variable_c.json", &variable)
+
+	plan := resourceVariableModel{
+		Name:          types.StringValue("test-empty-constant"),
+		Type:          types.StringValue("c"),
+		Id:            types.StringUnknown(),
+		Notes:         types.StringNull(),
+		Parameter:     []ResourceParameterModel{templateParameter("value", types.StringValue(""))},
+		ParameterJson: types.StringNull(),
+		Parameters:    types.StringNull(),
+	}
+	state := toResourceVariable(&variable, plan)
+
+	assert.Equal(t, plan.Parameter, state.Parameter)
+}
@@ -6,6 +6,8 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"google.golang.org/api/tagmanager/v2"
 )
@@ -40,6 +42,8 @@ func (r *workspaceResource) Metadata(_ context.Context, req resource.MetadataReq
 func (r *workspaceResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
+			"account_id":   accountIdSchema,
+			"container_id": containerIdSchema,
 			"name": schema.StringAttribute{
 				Description: "The name of the workspace.",
 				Required:    true,
@@ -49,23 +53,55 @@ func (r *workspaceResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				Optional:    true,
 			},
 			"id": schema.StringAttribute{
-				Description: "The ID of the workspace.",
+				Description:   "The ID of the workspace.",
+				Computed:      true,
+				PlanModifiers: idPlanModifiers,
+			},
+			"fingerprint": schema.StringAttribute{
+				Description: "The fingerprint of the workspace, which changes whenever the workspace is modified.",
+				Computed:    true,
+			},
+			"path": schema.StringAttribute{
+				Description: "The API relative path of the workspace.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"tag_manager_url": schema.StringAttribute{
+				Description: "The link to the workspace in the Tag Manager UI.",
 				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 		},
 	}
 }
 
 type workspaceResourceModel struct {
+	AccountId   types.String `tfsdk:"account_id"`
+	ContainerId types.String `tfsdk:"container_id"`
 	Name        types.String `tfsdk:"name"`
 	Description types.String `tfsdk:"description"`
 	Id          types.String `tfsdk:"id"`
+	Fingerprint types.String `tfsdk:"fingerprint"`
+	Path        types.String `tfsdk:"path"`
+	Url         types.String `tfsdk:"tag_manager_url"`
 }
 
+// overwriteWorkspaceResource sets the resource to the workspace. The API
+// returns no description as an empty string, which stays null unless the
+// resource had an empty description.
 func overwriteWorkspaceResource(workspace *tagmanager.Workspace, resource *workspaceResourceModel) {
+	resource.AccountId = types.StringValue(workspace.AccountId)
+	resource.ContainerId = types.StringValue(workspace.ContainerId)
 	resource.Name = types.StringValue(workspace.Name)
-	resource.Description = types.StringValue(workspace.Description)
+	resource.Description = priorStringValue(workspace.Description, resource.Description)
 	resource.Id = types.StringValue(workspace.WorkspaceId)
+	resource.Fingerprint = types.StringValue(workspace.Fingerprint)
+	resource.Path = types.StringValue(workspace.Path)
+	resource.Url = types.StringValue(workspace.TagManagerUrl)
 }
 
 // Create creates the resource and sets the initial Terraform state.
@@ -77,7 +113,8 @@ func (r *workspaceResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
-	workspace, err := r.client.CreateWorkspace(&tagmanager.Workspace{
+	client := r.client.WithContext(ctx).InContainer(plan.AccountId.ValueString(), plan.ContainerId.ValueString())
+	workspace, err := client.CreateWorkspace(&tagmanager.Workspace{
 		Name:        plan.Name.ValueString(),
 		Description: plan.Description.ValueString(),
 	})
@@ -104,7 +141,8 @@ func (r *workspaceResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	workspace, err := r.client.Workspace(state.Id.ValueString())
+	client := r.client.WithContext(ctx).InContainer(state.AccountId.ValueString(), state.ContainerId.ValueString())
+	workspace, err := client.Workspace(state.Id.ValueString())
 	if err == api.ErrNotExist {
 		resp.State.RemoveResource(ctx)
 		return
@@ -114,7 +152,7 @@ func (r *workspaceResource) Read(ctx context.Context, req resource.ReadRequest,
 	}
 
 	overwriteWorkspaceResource(workspace, &state)
-	diags = req.State.Set(ctx, &state)
+	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -134,7 +172,8 @@ func (r *workspaceResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
-	workspace, err := r.client.UpdateWorkspaces(state.Id.ValueString(), &tagmanager.Workspace{
+	client := r.client.WithContext(ctx).InContainer(state.AccountId.ValueString(), state.ContainerId.ValueString())
+	workspace, err := client.UpdateWorkspaces(state.Id.ValueString(), &tagmanager.Workspace{
 		Name:        plan.Name.ValueString(),
 		Description: plan.Description.ValueString(),
 	})
@@ -160,7 +199,8 @@ func (r *workspaceResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
-	err := r.client.DeleteWorkspace(state.Id.ValueString())
+	client := r.client.WithContext(ctx).InContainer(state.AccountId.ValueString(), state.ContainerId.ValueString())
+	err := client.DeleteWorkspace(state.Id.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error Deleting Workspace", err.Error())
 		return
@@ -0,0 +1,40 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/tagmanager/v2"
+)
+
+func TestOverwriteWorkspaceResourceDescription(t *testing.T) {
+	workspace := &tagmanager.Workspace{
+		AccountId:     "6105084028",
+		ContainerId:   "119458552",
+		WorkspaceId:   "12",
+		Name:          "my-workspace",
+		Fingerprint:   "1697703011352",
+		Path:          "accounts/6105084028/containers/119458552/workspaces/12",
+		TagManagerUrl: "https://tagmanager.google.com/#/container/accounts/6105084028/containers/119458552/workspaces/12?apiLink=workspace",
+	}
+
+	// No description stays null.
+	resource := workspaceResourceModel{Description: types.StringNull()}
+	overwriteWorkspaceResource(workspace, &resource)
+	assert.True(t, resource.Description.IsNull())
+	assert.Equal(t, types.StringValue(workspace.Path), resource.Path)
+	assert.Equal(t, types.StringValue(workspace.Fingerprint), resource.Fingerprint)
+	assert.Equal(t, types.StringValue(workspace.TagManagerUrl), resource.Url)
+
+	// An empty description stays empty.
+	resource = workspaceResourceModel{Description: types.StringValue("")}
+	overwriteWorkspaceResource(workspace, &resource)
+	assert.Equal(t, types.StringValue(""), resource.Description)
+
+	// A description changed outside of Terraform is detected.
+	workspace.Description = "changed in the UI"
+	resource = workspaceResourceModel{Description: types.StringNull()}
+	overwriteWorkspaceResource(workspace, &resource)
+	assert.Equal(t, types.StringValue("changed in the UI"), resource.Description)
+}
@@ -0,0 +1,369 @@
+package provider
+
+import (
+	"context"
+	"terraform-provider-google-tag-manager/internal/api"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/api/tagmanager/v2"
+)
+
+var (
+	_ resource.ResourceWithConfigure      = &zoneResource{}
+	_ resource.ResourceWithModifyPlan     = &zoneResource{}
+	_ resource.ResourceWithUpgradeState   = &zoneResource{}
+	_ resource.ResourceWithValidateConfig = &zoneResource{}
+)
+
+func NewZoneResource() resource.Resource {
+	return &zoneResource{}
+}
+
+type zoneResource struct {
+	client *api.ClientInWorkspace
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *zoneResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.client = req.ProviderData.(*api.ClientInWorkspace)
+}
+
+// Metadata returns the resource type name.
+func (r *zoneResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_zone"
+}
+
+var zoneResourceSchemaAttributes = map[string]schema.Attribute{
+	"account_id":   accountIdSchema,
+	"container_id": containerIdSchema,
+	"workspace_id": workspaceIdSchema,
+	"name": schema.StringAttribute{
+		Description: "The name of the zone.",
+		Required:    true,
+	},
+	"id": schema.StringAttribute{
+		Description:   "The ID of the zone.",
+		Computed:      true,
+		PlanModifiers: idPlanModifiers,
+	},
+	"notes": schema.StringAttribute{
+		Description: "The notes of the zone.",
+		Optional:    true,
+	},
+	"child_container": schema.ListNestedAttribute{
+		Description: "The containers that are children of the zone.",
+		Optional:    true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"public_id": schema.StringAttribute{
+					Description: "The public ID of the child container, e.g. GTM-XXXX.",
+					Required:    true,
+				},
+				"nickname": schema.StringAttribute{
+					Description: "The zone's nickname for the child container.",
+					Optional:    true,
+				},
+			},
+		},
+	},
+	"boundary": schema.SingleNestedAttribute{
+		Description: "The boundary of the zone, i.e. where the child containers are loaded.",
+		Optional:    true,
+		Attributes: map[string]schema.Attribute{
+			"condition": conditionSchema,
+			"custom_evaluation_trigger_id": schema.ListAttribute{
+				Description: "The IDs of the triggers that evaluate the conditions, in addition to the built-in ones.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+		},
+	},
+	"type_restriction": schema.SingleNestedAttribute{
+		Description: "The tag and variable types allowed in the child containers.",
+		Optional:    true,
+		Attributes: map[string]schema.Attribute{
+			"enable": schema.BoolAttribute{
+				Description: "Whether the type restriction is enabled.",
+				Required:    true,
+			},
+			"whitelisted_type_id": schema.ListAttribute{
+				Description: "The IDs of the allowed types.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+		},
+	},
+}
+
+// Schema defines the schema for the resource.
+func (r *zoneResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version:    parameterSchemaVersion,
+		Attributes: zoneResourceSchemaAttributes,
+	}
+}
+
+// UpgradeState upgrades the state from prior schema versions.
+func (r *zoneResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: parameterStateUpgrader(zoneResourceSchemaAttributes, func() interface{} { return &resourceZoneModel{} }),
+	}
+}
+
+// ValidateConfig checks the condition parameters of the boundary.
+func (r *zoneResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	resp.Diagnostics.Append(validateConfigConditions(ctx, req.Config, path.Root("boundary").AtName("condition"))...)
+}
+
+// ModifyPlan checks the variable references of the zone against the
+// workspace.
+func (r *zoneResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	resp.Diagnostics.Append(checkPlanVariableReferences(ctx, r.client, req)...)
+}
+
+type resourceZoneChildContainerModel struct {
+	PublicId types.String `tfsdk:"public_id"`
+	Nickname types.String `tfsdk:"nickname"`
+}
+
+type resourceZoneBoundaryModel struct {
+	Condition                 []resourceConditionModel `tfsdk:"condition"`
+	CustomEvaluationTriggerId []types.String           `tfsdk:"custom_evaluation_trigger_id"`
+}
+
+type resourceZoneTypeRestrictionModel struct {
+	Enable            types.Bool     `tfsdk:"enable"`
+	WhitelistedTypeId []types.String `tfsdk:"whitelisted_type_id"`
+}
+
+type resourceZoneModel struct {
+	AccountId       types.String                      `tfsdk:"account_id"`
+	ContainerId     types.String                      `tfsdk:"container_id"`
+	WorkspaceId     types.String                      `tfsdk:"workspace_id"`
+	Name            types.String                      `tfsdk:"name"`
+	Id              types.String                      `tfsdk:"id"`
+	Notes           types.String                      `tfsdk:"notes"`
+	ChildContainer  []resourceZoneChildContainerModel `tfsdk:"child_container"`
+	Boundary        *resourceZoneBoundaryModel        `tfsdk:"boundary"`
+	TypeRestriction *resourceZoneTypeRestrictionModel `tfsdk:"type_restriction"`
+}
+
+func toResourceZone(zone *tagmanager.Zone, prior resourceZoneModel) resourceZoneModel {
+	priorNickname := map[string]types.String{}
+	for _, c := range prior.ChildContainer {
+		priorNickname[c.PublicId.ValueString()] = c.Nickname
+	}
+
+	var childContainer []resourceZoneChildContainerModel
+	if len(zone.ChildContainer) == 0 && prior.ChildContainer != nil && len(prior.ChildContainer) == 0 {
+		childContainer = prior.ChildContainer
+	}
+	for _, c := range zone.ChildContainer {
+		childContainer = append(childContainer, resourceZoneChildContainerModel{
+			PublicId: types.StringValue(c.PublicId),
+			Nickname: priorStringValue(c.Nickname, priorNickname[c.PublicId]),
+		})
+	}
+
+	var boundary *resourceZoneBoundaryModel
+	if zone.Boundary != nil {
+		var priorBoundary resourceZoneBoundaryModel
+		if prior.Boundary != nil {
+			priorBoundary = *prior.Boundary
+		}
+
+		var condition []resourceConditionModel
+		if len(zone.Boundary.Condition) != 0 {
+			condition = toResourceCondition(zone.Boundary.Condition)
+		} else if priorBoundary.Condition != nil && len(priorBoundary.Condition) == 0 {
+			condition = priorBoundary.Condition
+		}
+
+		boundary = &resourceZoneBoundaryModel{
+			Condition:                 condition,
+			CustomEvaluationTriggerId: priorStringArray(zone.Boundary.CustomEvaluationTriggerId, priorBoundary.CustomEvaluationTriggerId),
+		}
+	}
+
+	var typeRestriction *resourceZoneTypeRestrictionModel
+	if zone.TypeRestriction != nil {
+		var priorWhitelistedTypeId []types.String
+		if prior.TypeRestriction != nil {
+			priorWhitelistedTypeId = prior.TypeRestriction.WhitelistedTypeId
+		}
+
+		typeRestriction = &resourceZoneTypeRestrictionModel{
+			Enable:            types.BoolValue(zone.TypeRestriction.Enable),
+			WhitelistedTypeId: priorStringArray(zone.TypeRestriction.WhitelistedTypeId, priorWhitelistedTypeId),
+		}
+	}
+
+	return resourceZoneModel{
+		AccountId:       types.StringValue(zone.AccountId),
+		ContainerId:     types.StringValue(zone.ContainerId),
+		WorkspaceId:     types.StringValue(zone.WorkspaceId),
+		Name:            types.StringValue(zone.Name),
+		Id:              types.StringValue(zone.ZoneId),
+		Notes:           priorStringValue(zone.Notes, prior.Notes),
+		ChildContainer:  childContainer,
+		Boundary:        boundary,
+		TypeRestriction: typeRestriction,
+	}
+}
+
+func toApiZone(resource resourceZoneModel) *tagmanager.Zone {
+	var childContainer []*tagmanager.ZoneChildContainer
+	for _, c := range resource.ChildContainer {
+		childContainer = append(childContainer, &tagmanager.ZoneChildContainer{
+			PublicId: c.PublicId.ValueString(),
+			Nickname: c.Nickname.ValueString(),
+		})
+	}
+
+	var boundary *tagmanager.ZoneBoundary
+	if resource.Boundary != nil {
+		boundary = &tagmanager.ZoneBoundary{
+			Condition:                 toApiCondition(resource.Boundary.Condition),
+			CustomEvaluationTriggerId: unwrapStringArray(resource.Boundary.CustomEvaluationTriggerId),
+		}
+	}
+
+	var typeRestriction *tagmanager.ZoneTypeRestriction
+	if resource.TypeRestriction != nil {
+		typeRestriction = &tagmanager.ZoneTypeRestriction{
+			Enable:            resource.TypeRestriction.Enable.ValueBool(),
+			WhitelistedTypeId: unwrapStringArray(resource.TypeRestriction.WhitelistedTypeId),
+		}
+	}
+
+	return &tagmanager.Zone{
+		Name:            resource.Name.ValueString(),
+		ZoneId:          resource.Id.ValueString(),
+		Notes:           resource.Notes.ValueString(),
+		ChildContainer:  childContainer,
+		Boundary:        boundary,
+		TypeRestriction: typeRestriction,
+	}
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *zoneResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan resourceZoneModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := clientInWorkspace(ctx, r.client, plan.AccountId, plan.ContainerId, plan.WorkspaceId)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Creating Zone", err.Error())
+		return
+	}
+
+	zone, err := client.CreateZone(toApiZone(plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Creating Zone", err.Error())
+		return
+	}
+
+	diags = resp.State.Set(ctx, toResourceZone(zone, plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *zoneResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state resourceZoneModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := clientInWorkspace(ctx, r.client, state.AccountId, state.ContainerId, state.WorkspaceId)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Reading Zone", err.Error())
+		return
+	}
+
+	zone, err := client.Zone(state.Id.ValueString())
+	if err == api.ErrNotExist {
+		resp.State.RemoveResource(ctx)
+		return
+	} else if err != nil {
+		resp.Diagnostics.AddError("Error Reading Zone", err.Error())
+		return
+	}
+
+	diags = resp.State.Set(ctx, toResourceZone(zone, state))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *zoneResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state resourceZoneModel
+
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+
+	diags = req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := clientInWorkspace(ctx, r.client, state.AccountId, state.ContainerId, state.WorkspaceId)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Updating Zone", err.Error())
+		return
+	}
+
+	zone, err := client.UpdateZone(state.Id.ValueString(), toApiZone(plan))
+	if err != nil {
+		resp.Diagnostics.AddError("Error Updating Zone", err.Error())
+		return
+	}
+
+	diags = resp.State.Set(ctx, toResourceZone(zone, plan))
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *zoneResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state resourceZoneModel
+
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, err := clientInWorkspace(ctx, r.client, state.AccountId, state.ContainerId, state.WorkspaceId)
+	if err != nil {
+		resp.Diagnostics.AddError("Error Deleting Zone", err.Error())
+		return
+	}
+
+	err = client.DeleteZone(state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error Deleting Zone", err.Error())
+		return
+	}
+}
@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/tagmanager/v2"
+)
+
+func TestToResourceZoneKeepsEmptyValues(t *testing.T) {
+	zone := &tagmanager.Zone{
+		AccountId:       "6105084028",
+		ContainerId:     "119458552",
+		WorkspaceId:     "12",
+		ZoneId:          "2",
+		Name:            "test-zone",
+		ChildContainer:  []*tagmanager.ZoneChildContainer{{PublicId: "GTM-TEST"}},
+		Boundary:        &tagmanager.ZoneBoundary{},
+		TypeRestriction: &tagmanager.ZoneTypeRestriction{Enable: true},
+	}
+	prior := resourceZoneModel{
+		Notes: types.StringValue(""),
+		ChildContainer: []resourceZoneChildContainerModel{
+			{PublicId: types.StringValue("GTM-TEST"), Nickname: types.StringValue("")},
+		},
+		Boundary: &resourceZoneBoundaryModel{
+			Condition:                 []resourceConditionModel{},
+			CustomEvaluationTriggerId: []types.String{},
+		},
+		TypeRestriction: &resourceZoneTypeRestrictionModel{WhitelistedTypeId: []types.String{}},
+	}
+
+	state := toResourceZone(zone, prior)
+	assert.Equal(t, types.StringValue(""), state.Notes)
+	assert.Equal(t, types.StringValue(""), state.ChildContainer[0].Nickname)
+	assert.Equal(t, []resourceConditionModel{}, state.Boundary.Condition)
+	assert.Equal(t, []types.String{}, state.Boundary.CustomEvaluationTriggerId)
+	assert.Equal(t, []types.String{}, state.TypeRestriction.WhitelistedTypeId)
+
+	state = toResourceZone(zone, resourceZoneModel{})
+	assert.True(t, state.Notes.IsNull())
+	assert.True(t, state.ChildContainer[0].Nickname.IsNull())
+	assert.Nil(t, state.Boundary.Condition)
+	assert.Nil(t, state.Boundary.CustomEvaluationTriggerId)
+	assert.Nil(t, state.TypeRestriction.WhitelistedTypeId)
+}